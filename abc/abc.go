@@ -1,38 +1,48 @@
+// Package abc reads and writes music in the ABC notation.
+//
+// See https://abcnotation.com/wiki/abc:standard:v2.1 for the standard.
 package abc
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/ArnaudCalmettes/gohar"
 )
 
+// ScaleToABC returns the notes of the scale upwards from given root, which is
+// written in the octave of middle C (so that C♭ is written as "_C"), separated
+// by spaces.
 func ScaleToABC(root gohar.PitchClass, pattern gohar.ScalePattern) string {
-	var w strings.Builder
-	rootBase := root.Base()
-	for pc := range pattern.PitchClasses(root) {
-		var oct int8
-		if pc.Base() < rootBase {
-			oct = 1
-		}
-		fmt.Fprintf(&w, "%s ", NoteToABC(gohar.Note{PitchClass: pc, Oct: oct}))
+	tonic := gohar.Note{PitchClass: root}
+	tonic.Oct -= tonic.BaseOctave()
+	var names []string
+	for n := range pattern.Notes(tonic) {
+		names = append(names, NoteToABC(n))
 	}
-	return w.String()
+	return strings.Join(names, " ")
 }
 
+// NoteToABC returns the note with its accidental: C is middle C, c is the C
+// an octave above, and octave marks (' and ,) move notes further up or down.
+// The octave is the one of the note's base, so that B♯ is written next to B.
 func NoteToABC(n gohar.Note) string {
 	name := PitchClassToABC(n.PitchClass)
-	switch n.Oct {
-	case 0:
-		return strings.ToUpper(name)
-	case 1:
-		return strings.ToLower(name)
-	}
-	if n.Oct < 0 {
-		return name + strings.Repeat(",", int(-n.Oct))
+	if oct := n.BaseOctave(); oct > 0 {
+		return strings.ToLower(name) + strings.Repeat("'", int(oct-1))
 	} else {
-		return name + strings.Repeat("'", int(n.Oct))
+		return strings.ToUpper(name) + strings.Repeat(",", int(-oct))
+	}
+}
+
+// ChordToABC returns the notes played together, such as "[CEG]".
+func ChordToABC(notes []gohar.Note) string {
+	var w strings.Builder
+	w.WriteByte('[')
+	for _, n := range notes {
+		w.WriteString(NoteToABC(n))
 	}
+	w.WriteByte(']')
+	return w.String()
 }
 
 func PitchClassToABC(pc gohar.PitchClass) string {
@@ -52,3 +62,33 @@ func abcAlt(alt gohar.Pitch) string {
 	}
 	return ""
 }
+
+// KeyToABC returns the key as the value of a K: field, such as "Bb", "F#m" or
+// "DDor". Harmonic and melodic minor keys are written as minor keys.
+func KeyToABC(k gohar.Key) string {
+	mode := k.Mode()
+	switch mode {
+	case "", "major":
+		mode = ""
+	case "minor":
+		mode = "m"
+	default:
+		// "Dor", "Mix"...
+		mode = strings.ToUpper(mode[:1]) + mode[1:3]
+	}
+	alt := int(k.Root.Alt())
+	return string(k.Root.BaseName()) + strings.Repeat("#", max(alt, 0)) + strings.Repeat("b", max(-alt, 0)) + mode
+}
+
+// ChordSymbolToABC returns the chord symbol in quotes, such as "\"Bb7\"",
+// with ASCII accidentals.
+func ChordSymbolToABC(c gohar.Chord) string {
+	return `"` + chordSymbolAlts.Replace(c.String()) + `"`
+}
+
+var chordSymbolAlts = strings.NewReplacer(
+	gohar.AltDoubleFlat, "bb",
+	gohar.AltDoubleSharp, "##",
+	gohar.AltFlat, "b",
+	gohar.AltSharp, "#",
+)
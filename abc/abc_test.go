@@ -0,0 +1,29 @@
+package abc
+
+import (
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestScaleToABC(t *testing.T) {
+	testCases := []struct {
+		Root    gohar.PitchClass
+		Pattern gohar.ScalePattern
+		Want    string
+	}{
+		{gohar.PitchClassC, gohar.ScalePatternMajor, "C D E F G A B"},
+		{gohar.PitchClassD, gohar.ScalePatternMajor, "D E ^F G A B ^c"},
+		{gohar.PitchClassB.Sharp(), gohar.ScalePatternMajor, "^B ^^c ^^d ^e ^^f ^^g ^^a"},
+		{gohar.PitchClassC.Flat(), gohar.ScalePatternMajor, "_C _D _E _F _G _A _B"},
+		{gohar.PitchClassC.Sharp(), gohar.ScalePatternMajor, "^C ^D ^E ^F ^G ^A ^B"},
+		{gohar.PitchClassA, gohar.ScalePatternNaturalMinor, "A B c d e f g"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Root.String(), func(t *testing.T) {
+			Expect(t, Equal(tc.Want, ScaleToABC(tc.Root, tc.Pattern)))
+		})
+	}
+}
@@ -0,0 +1,669 @@
+package abc
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+// ParseTunes parses every tune of an ABC file. Each tune starts with an X:
+// field, and anything before the first tune is ignored. If there is no X:
+// field at all, the whole input is parsed as a single tune.
+//
+// Errors are the same as [ParseTune].
+func ParseTunes(input string) ([]Tune, error) {
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+	var starts []int
+	for i, line := range lines {
+		if strings.HasPrefix(line, "X:") {
+			starts = append(starts, i)
+		}
+	}
+	if len(starts) == 0 {
+		t, err := ParseTune(input)
+		if err != nil {
+			return nil, err
+		}
+		return []Tune{t}, nil
+	}
+	tunes := make([]Tune, 0, len(starts))
+	for i, start := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		t, err := parseTune(lines[start:end], start+1)
+		if err != nil {
+			return nil, err
+		}
+		tunes = append(tunes, t)
+	}
+	return tunes, nil
+}
+
+// ParseTune parses a single-voice ABC tune into a sequence of spelled notes. The
+// X:, T:, M:, L: and K: fields are read, and other fields are ignored. Notes are
+// spelled after the key signature and the accidentals met earlier in the bar,
+// tied notes are merged, and broken rhythms and tuplets are applied.
+//
+// Chord symbols that can't be parsed (see [gohar.ParseChord]), annotations,
+// decorations, grace notes and slurs are ignored. Changes of meter or unit note
+// length within the tune are only taken into account for parsing lengths.
+//
+// ErrInvalidTune is returned if the tune is malformed or has several voices.
+func ParseTune(input string) (Tune, error) {
+	return parseTune(strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n"), 1)
+}
+
+func parseTune(lines []string, firstLine int) (Tune, error) {
+	p := &parser{
+		tune:    Tune{Music: gohar.Sequence{TimeSignature: gohar.TimeSignatureCommon}},
+		keyAlts: make(map[byte]gohar.Pitch),
+		barAlts: make(map[[2]int8]gohar.Pitch),
+		tied:    -1,
+	}
+	header := true
+	for i, line := range lines {
+		p.line = firstLine + i
+		if j := strings.IndexByte(line, '%'); j >= 0 {
+			line = line[:j]
+		}
+		line = strings.TrimRight(line, " \t")
+		if isField(line) {
+			if err := p.field(line[0], strings.TrimSpace(line[2:]), header); err != nil {
+				return Tune{}, err
+			}
+			if line[0] == 'K' {
+				header = false
+			}
+			continue
+		}
+		if header {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			// the body starts without a K: field
+			header = false
+			p.setDefaultUnitLength()
+		}
+		if strings.TrimSpace(line) == "" {
+			// an empty line ends the tune
+			break
+		}
+		if err := p.music(strings.TrimSuffix(line, "\\")); err != nil {
+			return Tune{}, err
+		}
+	}
+	if p.tune.UnitLength.IsZero() {
+		p.setDefaultUnitLength()
+	}
+	return p.tune, nil
+}
+
+func isField(line string) bool {
+	return len(line) >= 2 && line[1] == ':' &&
+		(line[0] >= 'A' && line[0] <= 'Z' || line[0] >= 'a' && line[0] <= 'z')
+}
+
+type parser struct {
+	tune Tune
+	line int
+	unit gohar.Duration
+	// keyAlts are the alterations of the key signature, by base name.
+	keyAlts map[byte]gohar.Pitch
+	// barAlts are the alterations met in the current bar, by base and octave.
+	barAlts map[[2]int8]gohar.Pitch
+	harmony *gohar.Chord
+	// tied is the index of the event tied to the next one, or -1.
+	tied int
+	// broken is the multiplier of the next event's length (as a fraction),
+	// after a broken rhythm.
+	broken gohar.Duration
+	// tuplet is the multiplier of the lengths of the next tupletNotes events.
+	tuplet      gohar.Duration
+	tupletNotes int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidTune, p.line, fmt.Sprintf(format, args...))
+}
+
+// field reads a header field, or a field within the body (either on its own
+// line or inline).
+func (p *parser) field(name byte, value string, header bool) error {
+	switch name {
+	case 'X':
+		if header {
+			p.tune.Number, _ = strconv.Atoi(value)
+		}
+	case 'T':
+		if header && p.tune.Title == "" {
+			p.tune.Title = value
+		}
+	case 'M':
+		ts, err := parseMeter(value)
+		if err != nil {
+			return p.errorf("%v", err)
+		}
+		if header {
+			p.tune.Music.TimeSignature = ts
+		}
+	case 'L':
+		l, err := parseLength(value)
+		if err != nil {
+			return p.errorf("%v", err)
+		}
+		p.unit = l
+		if header {
+			p.tune.UnitLength = l
+		}
+	case 'K':
+		key, err := parseKey(value)
+		if err != nil {
+			return p.errorf("%v", err)
+		}
+		if header {
+			p.tune.Key = &key
+			if p.unit.IsZero() {
+				p.setDefaultUnitLength()
+			}
+		}
+		clear(p.keyAlts)
+		for _, pc := range key.Signature() {
+			p.keyAlts[pc.BaseName()] = pc.Alt()
+		}
+	case 'V':
+		if !header {
+			return p.errorf("multiple voices aren't supported")
+		}
+	}
+	return nil
+}
+
+// setDefaultUnitLength sets the unit note length after the meter: 1/16 if the
+// meter is less than 3/4, or 1/8 otherwise.
+func (p *parser) setDefaultUnitLength() {
+	if p.unit.IsZero() {
+		p.unit = gohar.DurationEighth
+		if p.tune.Music.TimeSignature.Bar().Compare(gohar.NewDuration(3, 4)) < 0 {
+			p.unit = gohar.DurationSixteenth
+		}
+	}
+	if p.tune.UnitLength.IsZero() {
+		p.tune.UnitLength = p.unit
+	}
+}
+
+// parseMeter parses a meter such as "6/8", "2+3/8", "C" (4/4) or "C|" (2/2).
+// Free meters ("none") are considered as 4/4.
+func parseMeter(value string) (gohar.TimeSignature, error) {
+	switch value {
+	case "", "none":
+		return gohar.TimeSignatureCommon, nil
+	}
+	if ts, err := gohar.ParseTimeSignature(value); err == nil {
+		return ts, nil
+	}
+	num, den, ok := strings.Cut(value, "/")
+	if !ok {
+		return gohar.TimeSignature{}, fmt.Errorf("invalid meter %q", value)
+	}
+	beats := 0
+	for term := range strings.SplitSeq(strings.Trim(num, "()"), "+") {
+		n, err := strconv.Atoi(strings.TrimSpace(term))
+		if err != nil || n <= 0 {
+			return gohar.TimeSignature{}, fmt.Errorf("invalid meter %q", value)
+		}
+		beats += n
+	}
+	beatType, err := strconv.Atoi(strings.TrimSpace(den))
+	if err != nil {
+		return gohar.TimeSignature{}, fmt.Errorf("invalid meter %q", value)
+	}
+	return gohar.NewTimeSignature(beats, beatType)
+}
+
+// parseLength parses a unit note length such as "1/8".
+func parseLength(value string) (gohar.Duration, error) {
+	num, den, ok := strings.Cut(value, "/")
+	n, err := strconv.Atoi(num)
+	if err != nil || n <= 0 {
+		return gohar.Duration{}, fmt.Errorf("invalid unit note length %q", value)
+	}
+	d := 1
+	if ok {
+		if d, err = strconv.Atoi(den); err != nil || d <= 0 {
+			return gohar.Duration{}, fmt.Errorf("invalid unit note length %q", value)
+		}
+	}
+	return gohar.NewDuration(n, d), nil
+}
+
+// parseKey parses a key such as "G", "F#m", "Bb dorian" or "Emix". Clefs,
+// explicit accidentals and other modifiers that may follow are ignored, and
+// keys without a signature ("none") are considered as C major.
+func parseKey(value string) (gohar.Key, error) {
+	if value == "" || strings.HasPrefix(value, "none") || value[0] < 'A' || value[0] > 'G' {
+		return gohar.NewMajorKey(gohar.PitchClassC), nil
+	}
+	rest := value[1:]
+	var alt gohar.Pitch
+	switch {
+	case strings.HasPrefix(rest, "#"):
+		alt, rest = 1, rest[1:]
+	case strings.HasPrefix(rest, "b"):
+		alt, rest = -1, rest[1:]
+	}
+	tonic, err := gohar.NewPitchClassFromChar(value[0], alt)
+	if err != nil {
+		return gohar.Key{}, err
+	}
+	// the mode, if any, is the first word: the next ones are modifiers
+	var mode string
+	if words := strings.Fields(strings.ToLower(rest)); len(words) > 0 && !isKeyModifier(words[0]) {
+		mode = words[0]
+	}
+	switch {
+	case mode == "m":
+		mode = "min"
+	case len(mode) > 3:
+		// only the first three letters are significant
+		mode = mode[:3]
+	}
+	pattern, err := gohar.ParseKeyMode(mode)
+	if err != nil {
+		return gohar.Key{}, fmt.Errorf("invalid key %q", value)
+	}
+	return gohar.NewKey(tonic, pattern)
+}
+
+// keyClefs are the clefs that may follow a key.
+var keyClefs = []string{"treble", "bass", "alto", "tenor", "perc", "none"}
+
+// isKeyModifier returns true if a word that follows a key is a modifier rather
+// than a mode: a clef (possibly an octave up or down, such as "treble-8"), an
+// explicit accidental such as "^f", "exp", or a setting such as "transpose=-2".
+func isKeyModifier(word string) bool {
+	if word == "exp" || strings.ContainsAny(word, "^_=") {
+		return true
+	}
+	clef := strings.TrimSuffix(strings.TrimSuffix(word, "+8"), "-8")
+	return slices.Contains(keyClefs, clef)
+}
+
+// music reads a line of music.
+func (p *parser) music(line string) error {
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '`':
+			i++
+		case c == '"':
+			end := strings.IndexByte(line[i+1:], '"')
+			if end < 0 {
+				return p.errorf("unterminated chord symbol")
+			}
+			p.chordSymbol(line[i+1 : i+1+end])
+			i += end + 2
+		case c == '!' || c == '+':
+			end := strings.IndexByte(line[i+1:], c)
+			if end < 0 {
+				return p.errorf("unterminated decoration")
+			}
+			i += end + 2
+		case c == '{':
+			end := strings.IndexByte(line[i:], '}')
+			if end < 0 {
+				return p.errorf("unterminated grace notes")
+			}
+			i += end + 1
+		case c == '[' && i+2 < len(line) && isField(line[i+1:]):
+			end := strings.IndexByte(line[i:], ']')
+			if end < 0 {
+				return p.errorf("unterminated inline field")
+			}
+			if err := p.field(line[i+1], strings.TrimSpace(line[i+3:i+end]), false); err != nil {
+				return err
+			}
+			i += end + 1
+		case c == '|' || c == ':' || c == '[' && i+1 < len(line) && (line[i+1] == '|' || isDigit(line[i+1])):
+			i += barLineLength(line[i:])
+			clear(p.barAlts)
+		case c == '[':
+			n, err := p.chord(line[i:])
+			if err != nil {
+				return err
+			}
+			i += n
+		case c == '(':
+			i++
+			if i < len(line) && isDigit(line[i]) {
+				n, err := p.tupletSpec(line[i:])
+				if err != nil {
+					return err
+				}
+				i += n
+			}
+		case c == ')':
+			i++
+		case c == '-':
+			if len(p.tune.Music.Events) > 0 {
+				p.tied = len(p.tune.Music.Events) - 1
+			}
+			i++
+		case c == '>' || c == '<':
+			n := 1
+			for i+n < len(line) && line[i+n] == c {
+				n++
+			}
+			p.brokenRhythm(c, n)
+			i += n
+		case c == 'z' || c == 'x':
+			num, den, n := parseMultiplier(line[i+1:])
+			p.add(nil, p.unit.Scale(num, den))
+			i += n + 1
+		case c == 'Z' || c == 'X':
+			num, den, n := parseMultiplier(line[i+1:])
+			p.add(nil, p.tune.Music.TimeSignature.Bar().Scale(num, den))
+			i += n + 1
+		case isNoteStart(c):
+			note, length, n, err := p.note(line[i:])
+			if err != nil {
+				return err
+			}
+			p.add([]gohar.Note{note}, length)
+			i += n
+		case strings.IndexByte(".~HLMOPSTuvy", c) >= 0:
+			// decorations and spacers
+			i++
+		case c == '&':
+			return p.errorf("voice overlays aren't supported")
+		default:
+			return p.errorf("unexpected %q", line[i:i+1])
+		}
+	}
+	return nil
+}
+
+// barLineLength returns the length of the bar line at the start of the input,
+// with its repeat marks and ending numbers, such as "|", ":|2" or "[1". A "["
+// only belongs to the bar line if it starts a repeat ending or a bar line, so
+// that chords and inline fields can directly follow bar lines.
+func barLineLength(s string) int {
+	n := 1
+	for n < len(s) {
+		switch c := s[n]; {
+		case c == '[':
+			if n+1 >= len(s) || !isDigit(s[n+1]) && s[n+1] != '|' && s[n+1] != ']' {
+				return n
+			}
+		case strings.IndexByte("|:]0123456789,-", c) < 0:
+			return n
+		}
+		n++
+	}
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNoteStart(c byte) bool {
+	return c >= 'A' && c <= 'G' || c >= 'a' && c <= 'g' || c == '^' || c == '_' || c == '='
+}
+
+// chordSymbol reads a chord symbol. Annotations (that start with one of
+// ^_<>@) and unknown symbols are ignored.
+func (p *parser) chordSymbol(text string) {
+	if text == "" || strings.IndexByte("^_<>@", text[0]) >= 0 {
+		return
+	}
+	if c, err := gohar.ParseChord(text); err == nil {
+		p.harmony = &c
+	}
+}
+
+// note reads a note with its accidentals, octave marks and length multiplier,
+// and returns its length and the number of bytes read.
+func (p *parser) note(s string) (gohar.Note, gohar.Duration, int, error) {
+	i := 0
+	var (
+		alt      gohar.Pitch
+		explicit bool
+	)
+	for i < len(s) && strings.IndexByte("^_=", s[i]) >= 0 {
+		switch s[i] {
+		case '^':
+			alt++
+		case '_':
+			alt--
+		}
+		explicit = true
+		i++
+	}
+	if i >= len(s) || !(s[i] >= 'A' && s[i] <= 'G' || s[i] >= 'a' && s[i] <= 'g') {
+		return gohar.Note{}, gohar.Duration{}, 0, p.errorf("expected a note after accidental %q", s[:i])
+	}
+	letter := s[i]
+	var oct int8
+	if letter >= 'a' {
+		letter -= 'a' - 'A'
+		oct = 1
+	}
+	i++
+	for ; i < len(s) && (s[i] == '\'' || s[i] == ','); i++ {
+		if s[i] == '\'' {
+			oct++
+		} else {
+			oct--
+		}
+	}
+	num, den, n := parseMultiplier(s[i:])
+	i += n
+
+	base, err := gohar.NewPitchClassFromChar(letter, 0)
+	if err != nil {
+		return gohar.Note{}, gohar.Duration{}, 0, p.errorf("%v", err)
+	}
+	slot := [2]int8{int8(letter), oct}
+	switch {
+	case explicit:
+		p.barAlts[slot] = alt
+	case p.tiedAlt(slot) != nil:
+		// a tied note keeps its alteration over the bar line
+		alt = *p.tiedAlt(slot)
+	default:
+		var ok bool
+		if alt, ok = p.barAlts[slot]; !ok {
+			alt = p.keyAlts[letter]
+		}
+	}
+	pc, err := gohar.NewPitchClassFromChar(letter, alt)
+	if err != nil {
+		return gohar.Note{}, gohar.Duration{}, 0, p.errorf("%v", err)
+	}
+	return gohar.NoteWithPitch(pc, base.Pitch(oct)+alt), p.unit.Scale(num, den), i, nil
+}
+
+// tiedAlt returns the alteration of the note tied to a note of given base and
+// octave, if any.
+func (p *parser) tiedAlt(slot [2]int8) *gohar.Pitch {
+	if p.tied < 0 {
+		return nil
+	}
+	for _, n := range p.tune.Music.Events[p.tied].Notes {
+		if int8(n.BaseName()) == slot[0] && n.BaseOctave() == slot[1] {
+			alt := n.Alt()
+			return &alt
+		}
+	}
+	return nil
+}
+
+// chord reads notes played together, such as "[CEG]2", and returns the number
+// of bytes read. The chord lasts as long as its first note.
+func (p *parser) chord(s string) (int, error) {
+	var (
+		notes  []gohar.Note
+		length gohar.Duration
+	)
+	i := 1
+	for i < len(s) && s[i] != ']' {
+		switch {
+		case s[i] == ' ' || s[i] == '-':
+			i++
+		case isNoteStart(s[i]):
+			note, l, n, err := p.note(s[i:])
+			if err != nil {
+				return 0, err
+			}
+			if len(notes) == 0 {
+				length = l
+			}
+			notes = append(notes, note)
+			i += n
+		default:
+			return 0, p.errorf("unexpected %q in chord", s[i:i+1])
+		}
+	}
+	if i >= len(s) {
+		return 0, p.errorf("unterminated chord")
+	}
+	i++
+	num, den, n := parseMultiplier(s[i:])
+	p.add(notes, length.Scale(num, den))
+	return i + n, nil
+}
+
+// parseMultiplier parses a length multiplier such as "2", "/2", "3/2", "/" or
+// "//", and returns it as a fraction with the number of bytes read.
+func parseMultiplier(s string) (num, den, n int) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	num = 1
+	if i > 0 {
+		num, _ = strconv.Atoi(s[:i])
+	}
+	den = 1
+	for i < len(s) && s[i] == '/' {
+		i++
+		j := i
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		if j > i {
+			d, _ := strconv.Atoi(s[i:j])
+			den *= max(d, 1)
+		} else {
+			den *= 2
+		}
+		i = j
+	}
+	return num, den, i
+}
+
+// tupletSpec reads a tuplet such as "3" or "3:2:3" (after the opening
+// parenthesis), and returns the number of bytes read.
+func (p *parser) tupletSpec(s string) (int, error) {
+	var values [3]int
+	i, k := 0, 0
+	for k < 3 {
+		j := i
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		if j > i {
+			values[k], _ = strconv.Atoi(s[i:j])
+		}
+		i = j
+		k++
+		if i >= len(s) || s[i] != ':' {
+			break
+		}
+		i++
+	}
+	n, q, r := values[0], values[1], values[2]
+	if n < 2 || n > 9 {
+		return 0, p.errorf("invalid tuplet (%d", n)
+	}
+	if q == 0 {
+		switch n {
+		case 2, 4, 8:
+			q = 3
+		case 3, 6:
+			q = 2
+		default:
+			// 3 in compound meters, 2 otherwise
+			q = 2
+			if beats := p.tune.Music.TimeSignature.Beats; beats%3 == 0 && beats > 3 {
+				q = 3
+			}
+		}
+	}
+	if r == 0 {
+		r = n
+	}
+	p.tuplet, p.tupletNotes = gohar.NewDuration(q, n), r
+	return i, nil
+}
+
+// brokenRhythm lengthens the previous event and shortens the next one (for >),
+// or the other way around (for <).
+func (p *parser) brokenRhythm(c byte, n int) {
+	events := p.tune.Music.Events
+	if len(events) == 0 {
+		return
+	}
+	short := gohar.NewDuration(1, 1<<n)
+	long := gohar.NewDuration(2, 1).Sub(short)
+	if c == '<' {
+		short, long = long, short
+	}
+	last := &events[len(events)-1]
+	last.Duration = last.Duration.Scale(long.Num(), long.Den())
+	p.broken = short
+}
+
+// add adds an event, or extends the tied event if it has the same notes.
+func (p *parser) add(notes []gohar.Note, length gohar.Duration) {
+	if !p.broken.IsZero() {
+		length = length.Scale(p.broken.Num(), p.broken.Den())
+		p.broken = gohar.Duration{}
+	}
+	if p.tupletNotes > 0 {
+		length = length.Scale(p.tuplet.Num(), p.tuplet.Den())
+		p.tupletNotes--
+	}
+	tied := p.tied
+	p.tied = -1
+	if tied >= 0 && len(notes) > 0 && sameNotes(p.tune.Music.Events[tied].Notes, notes) {
+		e := &p.tune.Music.Events[tied]
+		e.Duration = e.Duration.Add(length)
+		return
+	}
+	events := p.tune.Music.Events
+	var start gohar.Duration
+	if len(events) > 0 {
+		start = events[len(events)-1].End()
+	}
+	p.tune.Music.Events = append(events, gohar.Event{Notes: notes, Start: start, Duration: length, Harmony: p.harmony})
+	p.harmony = nil
+}
+
+func sameNotes(a, b []gohar.Note) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Pitch() != b[i].Pitch() {
+			return false
+		}
+	}
+	return true
+}
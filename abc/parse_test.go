@@ -0,0 +1,169 @@
+package abc
+
+import (
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestParseTuneBarLines(t *testing.T) {
+	var (
+		c      = gohar.NoteC
+		e      = gohar.NoteE
+		g      = gohar.NoteG
+		fSharp = gohar.NoteF.Sharp()
+		d      = gohar.NoteD
+	)
+	testCases := []struct {
+		Name  string
+		Music string
+		Want  [][]gohar.Note
+	}{
+		{"chords after bar lines", "|[CEG]2 [CEG]2|[CEG]4|]", [][]gohar.Note{{c, e, g}, {c, e, g}, {c, e, g}}},
+		{"inline field after a bar line", "C D|[K:G]F G|", [][]gohar.Note{{c}, {d}, {fSharp}, {g}}},
+		{"repeat endings", "|:C D|1 E:|[2 F G|]", [][]gohar.Note{{c}, {d}, {e}, {gohar.NoteF}, {g}}},
+		{"chord after a repeat", "C2 :|[CEG]2|", [][]gohar.Note{{c}, {c, e, g}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tune, err := ParseTune("X:1\nL:1/4\nK:C\n" + tc.Music + "\n")
+			Require(t, NoError(err))
+			var got [][]gohar.Note
+			for _, e := range tune.Music.Events {
+				got = append(got, e.Notes)
+			}
+			Expect(t, Equal(tc.Want, got))
+		})
+	}
+}
+
+func TestParseTune(t *testing.T) {
+	input := `% a comment before the header
+X:7
+T:Reel
+T:Alternative title
+M:6/8
+L:1/16
+R:reel
+K:Bb dorian clef=treble
+"Cm7"c2 e>f (3gab B,,4- | B,,2 =e^e z4 d/ =d/ % comment
+"F7"[FAce]12 "^annotation"!trill!{g}~B12|]
+`
+	tune, err := ParseTune(input)
+	Require(t, NoError(err))
+	key, err := gohar.NewKey(gohar.PitchClassB.Flat(), gohar.ScalePatternDorian)
+	Require(t, NoError(err))
+	Expect(t,
+		Equal(7, tune.Number),
+		Equal("Reel", tune.Title),
+		Equal(gohar.DurationSixteenth, tune.UnitLength),
+		Equal(key, *tune.Key),
+		Equal(gohar.TimeSignature{Beats: 6, BeatType: 8}, tune.Music.TimeSignature),
+		Equal([]string{
+			"0+1/8 C1 Cm7",
+			"1/8+3/32 E♭1",
+			"7/32+1/32 F1",
+			"1/4+1/24 G1",
+			"7/24+1/24 A♭1",
+			"1/3+1/24 B♭1",
+			"3/8+3/8 B♭-2",
+			"3/4+1/16 E1",
+			"13/16+1/16 E♯1",
+			"7/8+1/4",
+			"9/8+1/32 D♭1",
+			"37/32+1/32 D1",
+			"19/16+3/4 F0 A♭0 C1 E♯1 F7",
+			"31/16+3/4 B♭0",
+		}, eventStrings(tune.Music)),
+	)
+}
+
+func TestParseTunes(t *testing.T) {
+	input := "%abc-2.1\nH:history is ignored\n\n" +
+		"X:1\nT:First\nK:G\nGAB|\n\n" +
+		"X:2\nT:Second\nM:C|\nK:Em\nE2|]\n"
+	tunes, err := ParseTunes(input)
+	Require(t, NoError(err))
+	Require(t, SliceHasLength(2, tunes))
+	Expect(t,
+		Equal("First", tunes[0].Title),
+		Equal(gohar.DurationEighth, tunes[0].UnitLength),
+		Equal([]string{"0+1/8 G0", "1/8+1/8 A0", "1/4+1/8 B0"}, eventStrings(tunes[0].Music)),
+		Equal("Second", tunes[1].Title),
+		Equal(gohar.TimeSignatureCut, tunes[1].Music.TimeSignature),
+		Equal(gohar.NewMinorKey(gohar.PitchClassE), *tunes[1].Key),
+		Equal([]string{"0+1/4 E0"}, eventStrings(tunes[1].Music)),
+	)
+
+	tunes, err = ParseTunes("M:2/4\nCD EF|")
+	Require(t, NoError(err))
+	Require(t, SliceHasLength(1, tunes))
+	Expect(t,
+		Equal(gohar.DurationSixteenth, tunes[0].UnitLength),
+		SliceHasLength(4, tunes[0].Music.Events),
+	)
+}
+
+func TestParseTuneKey(t *testing.T) {
+	bFlatMajor := gohar.NewMajorKey(gohar.PitchClassB.Flat())
+	gMajor := gohar.NewMajorKey(gohar.PitchClassG)
+	fSharpMinor := gohar.NewMinorKey(gohar.PitchClassF.Sharp())
+	dMixolydian, err := gohar.NewKey(gohar.PitchClassD, gohar.ScalePatternMixolydian)
+	Require(t, NoError(err))
+
+	testCases := []struct {
+		Field string
+		Want  gohar.Key
+	}{
+		{"Bb exp _e", bFlatMajor},
+		{"Bb ^f", bFlatMajor},
+		{"G clef=bass transpose=-2", gMajor},
+		{"G treble-8", gMajor},
+		{"F#m bass", fSharpMinor},
+		{"F# minor alto middle=c", fSharpMinor},
+		{"DMix perc", dMixolydian},
+		{"D mixolydian", dMixolydian},
+		{"none", gohar.NewMajorKey(gohar.PitchClassC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Field, func(t *testing.T) {
+			tune, err := ParseTune("X:1\nK:" + tc.Field + "\nC|")
+			Require(t, NoError(err))
+			Expect(t, Equal(tc.Want, *tune.Key))
+		})
+	}
+}
+
+func TestParseTuneInvalid(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+	}{
+		{"meter", "X:1\nM:3/5\nK:C\nC|"},
+		{"unit note length", "X:1\nL:0/8\nK:C\nC|"},
+		{"key", "X:1\nK:Cbebop\nC|"},
+		{"mode", "X:1\nK:C bebop clef=bass\nC|"},
+		{"unterminated chord symbol", "X:1\nK:C\n\"C7 C|"},
+		{"unterminated decoration", "X:1\nK:C\n!trill C|"},
+		{"unterminated grace notes", "X:1\nK:C\n{g C|"},
+		{"unterminated inline field", "X:1\nK:C\nC [K:G D|"},
+		{"unterminated chord", "X:1\nK:C\n[CEG"},
+		{"unexpected character in chord", "X:1\nK:C\n[CE*G]|"},
+		{"accidental without note", "X:1\nK:C\n^ C|"},
+		{"invalid tuplet", "X:1\nK:C\n(1C|"},
+		{"voices", "X:1\nK:C\nC|\nV:2\nE|"},
+		{"voice overlay", "X:1\nK:C\nC & E|"},
+		{"unexpected character", "X:1\nK:C\nC # D|"},
+		{"invalid field in a later tune", "X:1\nK:C\nC|\n\nX:2\nM:7\nK:C\nD|"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ParseTunes(tc.Input)
+			Expect(t, IsError(ErrInvalidTune, err))
+		})
+	}
+}
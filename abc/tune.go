@@ -0,0 +1,237 @@
+package abc
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+var ErrInvalidTune = errors.New("invalid ABC tune")
+
+// A Tune is a single-voice ABC tune.
+type Tune struct {
+	// Number is the reference number of the tune (X: field, 1 if zero).
+	Number int
+	// Title is the title of the tune (T: field), if any.
+	Title string
+	// UnitLength is the unit note length (L: field, 1/8 if zero).
+	UnitLength gohar.Duration
+	// Key is the key of the tune (K: field, C major if nil).
+	Key *gohar.Key
+	// Music is the music of the tune, written as a single voice (see
+	// [gohar.Sequence.Voice]) with the chord symbols of its events. Its time
+	// signature is the meter (M: field, 4/4 if zero).
+	Music gohar.Sequence
+}
+
+func (t Tune) unitLength() gohar.Duration {
+	if t.UnitLength.IsZero() {
+		return gohar.DurationEighth
+	}
+	return t.UnitLength
+}
+
+func (t Tune) key() gohar.Key {
+	if t.Key == nil {
+		return gohar.NewMajorKey(gohar.PitchClassC)
+	}
+	return *t.Key
+}
+
+// TuneToABC returns the tune in ABC notation, with its header and four bars per
+// line. Accidentals are written after the key signature and the accidentals
+// met earlier in the bar. Successive notes of tuplets are grouped in each bar,
+// such as "(3:2:3ABc" for triplet eighth notes.
+//
+// ErrInvalidTune is returned if the unit note length isn't positive,
+// gohar.ErrInvalidTimeSignature if the meter is invalid, and
+// gohar.ErrInvalidSequence if the music isn't a single voice or has tuplets of
+// more than 9 notes.
+func TuneToABC(t Tune) (string, error) {
+	ts := cmp.Or(t.Music.TimeSignature, gohar.DefaultTimeSignature)
+	if _, err := gohar.NewTimeSignature(ts.Beats, ts.BeatType); err != nil {
+		return "", err
+	}
+	unit := t.unitLength()
+	if unit.Compare(gohar.Duration{}) <= 0 {
+		return "", fmt.Errorf("%w: unit note length %s", ErrInvalidTune, unit)
+	}
+	voice, err := t.Music.Voice()
+	if err != nil {
+		return "", err
+	}
+	key := t.key()
+	if key.IsTheoretical() {
+		key = key.Enharmonic()
+	}
+
+	var w strings.Builder
+	fmt.Fprintf(&w, "X:%d\n", max(t.Number, 1))
+	if t.Title != "" {
+		fmt.Fprintf(&w, "T:%s\n", t.Title)
+	}
+	fmt.Fprintf(&w, "M:%s\n", ts)
+	fmt.Fprintf(&w, "L:%s\n", lengthString(unit))
+	fmt.Fprintf(&w, "K:%s\n", KeyToABC(key))
+
+	var (
+		acc    = newAccidentals(key)
+		bar    = 1
+		tuplet int // notes left in the current tuplet
+		music  []string
+	)
+	for i, e := range voice.Events {
+		if e.Duration.IsZero() {
+			continue
+		}
+		for ; bar < ts.Position(e.Start).Bar; bar++ {
+			if bar%4 == 0 {
+				music = append(music, "|\n")
+			} else {
+				music = append(music, "|")
+			}
+			acc.reset()
+		}
+		p, q, err := tupletRatio(e.Duration)
+		if err != nil {
+			return "", err
+		}
+		values := e.Duration.Scale(p, q).NoteValues()
+		var spec string
+		if p > 1 && tuplet == 0 {
+			tuplet = tupletNotes(voice.Events[i:], ts)
+			spec = fmt.Sprintf("(%d:%d:%d", p, q, tuplet)
+		}
+		for j, v := range values {
+			var n strings.Builder
+			if j == 0 {
+				n.WriteString(spec)
+				if e.Harmony != nil {
+					n.WriteString(ChordSymbolToABC(*e.Harmony))
+				}
+			}
+			switch len(e.Notes) {
+			case 0:
+				n.WriteByte('z')
+			case 1:
+				n.WriteString(acc.note(e.Notes[0]))
+			default:
+				n.WriteByte('[')
+				for _, note := range e.Notes {
+					n.WriteString(acc.note(note))
+				}
+				n.WriteByte(']')
+			}
+			n.WriteString(multiplier(v.Div(unit)))
+			if (j < len(values)-1 || e.Tied) && !e.IsRest() {
+				n.WriteByte('-')
+			}
+			music = append(music, n.String())
+		}
+		if p > 1 {
+			tuplet -= len(values)
+		}
+	}
+	music = append(music, "|]")
+	w.WriteString(strings.ReplaceAll(strings.Join(music, " "), "\n ", "\n"))
+	w.WriteByte('\n')
+	return w.String(), nil
+}
+
+// tupletRatio returns the tuplet of a duration, as p notes played in the time
+// of q, or 1:1 if the duration is written without tuplet.
+func tupletRatio(d gohar.Duration) (p, q int, err error) {
+	p, q = d.Den(), 1
+	for p%2 == 0 {
+		p /= 2
+	}
+	if p > 9 {
+		return 0, 0, fmt.Errorf("%w: cannot write %s as a tuplet", gohar.ErrInvalidSequence, d)
+	}
+	for q*2 < p {
+		q *= 2
+	}
+	return p, q, nil
+}
+
+// tupletNotes returns the number of notes of the tuplet that starts with the
+// first event: the notes of the following events of the same tuplet within the
+// bar.
+func tupletNotes(events []gohar.Event, ts gohar.TimeSignature) int {
+	p, q, _ := tupletRatio(events[0].Duration)
+	bar := ts.Position(events[0].Start).Bar
+	var n int
+	for _, e := range events {
+		if e.Duration.IsZero() {
+			continue
+		}
+		if ep, _, _ := tupletRatio(e.Duration); ep != p || ts.Position(e.Start).Bar != bar {
+			break
+		}
+		n += len(e.Duration.Scale(p, q).NoteValues())
+	}
+	return n
+}
+
+// lengthString returns a length as a fraction, such as "1/8" or "1/1".
+func lengthString(d gohar.Duration) string {
+	return fmt.Sprintf("%d/%d", d.Num(), d.Den())
+}
+
+// multiplier returns the ABC length multiplier of a note, such as "2", "/2"
+// or "3/2".
+func multiplier(num, den int) string {
+	switch {
+	case den == 1 && num == 1:
+		return ""
+	case den == 1:
+		return fmt.Sprint(num)
+	case num == 1:
+		return fmt.Sprintf("/%d", den)
+	}
+	return fmt.Sprintf("%d/%d", num, den)
+}
+
+// accidentals tracks the alterations implied by the key signature and by the
+// accidentals met in the current bar.
+type accidentals struct {
+	key map[byte]gohar.Pitch
+	bar map[[2]int8]gohar.Pitch // by base and octave
+}
+
+func newAccidentals(k gohar.Key) *accidentals {
+	acc := &accidentals{key: make(map[byte]gohar.Pitch), bar: make(map[[2]int8]gohar.Pitch)}
+	for _, pc := range k.Signature() {
+		acc.key[pc.BaseName()] = pc.Alt()
+	}
+	return acc
+}
+
+func (acc *accidentals) reset() {
+	clear(acc.bar)
+}
+
+// current returns the alteration that a note without accidental has.
+func (acc *accidentals) current(base byte, oct int8) gohar.Pitch {
+	if alt, ok := acc.bar[[2]int8{int8(base), oct}]; ok {
+		return alt
+	}
+	return acc.key[base]
+}
+
+// note returns the note, with an accidental if needed.
+func (acc *accidentals) note(n gohar.Note) string {
+	base, oct := n.BaseName(), n.BaseOctave()
+	s := NoteToABC(n)
+	if n.Alt() == acc.current(base, oct) {
+		return strings.TrimLeft(s, "^_")
+	}
+	acc.bar[[2]int8{int8(base), oct}] = n.Alt()
+	if n.Alt() == 0 {
+		return "=" + s
+	}
+	return s
+}
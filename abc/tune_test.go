@@ -0,0 +1,186 @@
+package abc
+
+import (
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestTuneToABC(t *testing.T) {
+	progression, err := gohar.ParseProgression("| Dm7 G7 | Cmaj7 |", 2)
+	Require(t, NoError(err))
+	dMajor := gohar.NewMajorKey(gohar.PitchClassD)
+	eDorian, err := gohar.NewKey(gohar.PitchClassE, gohar.ScalePatternDorian)
+	Require(t, NoError(err))
+	gSharpMajor := gohar.NewMajorKey(gohar.PitchClassG.Sharp())
+
+	testCases := []struct {
+		Name string
+		Tune func() Tune
+		Want string
+	}{
+		{
+			"empty",
+			func() Tune { return Tune{} },
+			"X:1\nM:4/4\nL:1/8\nK:C\n|]\n",
+		},
+		{
+			"header",
+			func() Tune {
+				var s gohar.Sequence
+				s.Append(gohar.DurationQuarter, gohar.NoteE.Octave(1))
+				return Tune{Number: 3, Title: "Jig", UnitLength: gohar.DurationSixteenth, Key: &eDorian, Music: s}
+			},
+			"X:3\nT:Jig\nM:4/4\nL:1/16\nK:EDor\ne4 |]\n",
+		},
+		{
+			"accidentals",
+			func() Tune {
+				s := gohar.Sequence{TimeSignature: gohar.TimeSignature{Beats: 2, BeatType: 4}}
+				for _, n := range []gohar.Note{
+					gohar.NoteF.Sharp(), gohar.NoteF, gohar.NoteF, gohar.NoteF.Octave(1),
+					gohar.NoteF, gohar.NoteC.Sharp().Octave(-1), gohar.NoteB.Flat().Octave(1), gohar.NoteE.DoubleSharp(),
+				} {
+					s.Append(gohar.DurationEighth, n)
+				}
+				return Tune{Key: &dMajor, Music: s}
+			},
+			"X:1\nM:2/4\nL:1/8\nK:D\nF =F F =f | =F C, _b ^^E |]\n",
+		},
+		{
+			"lengths, rests and ties",
+			func() Tune {
+				s := gohar.Sequence{TimeSignature: gohar.TimeSignatureWaltz}
+				s.Append(gohar.DurationSixteenth)
+				s.Append(gohar.DurationHalf.Add(gohar.DurationSixteenth), gohar.NoteG)
+				s.Append(gohar.DurationQuarter.Dotted(1), gohar.NoteA)
+				s.Events[2].Tied = true
+				s.Append(gohar.DurationEighth.Tuplet(3, 2), gohar.NoteA)
+				return Tune{Music: s}
+			},
+			"X:1\nM:3/4\nL:1/8\nK:C\nz/2 G4- G/2 A- | A2- (3:2:1A |]\n",
+		},
+		{
+			"tuplets",
+			func() Tune {
+				s := gohar.Sequence{TimeSignature: gohar.TimeSignature{Beats: 2, BeatType: 4}}
+				for _, n := range []gohar.Note{gohar.NoteC, gohar.NoteD, gohar.NoteE} {
+					s.Append(gohar.DurationEighth.Tuplet(3, 2), n)
+				}
+				s.Append(gohar.DurationQuarter.Tuplet(3, 2), gohar.NoteF)
+				s.Append(gohar.DurationEighth.Tuplet(3, 2))
+				for _, n := range []gohar.Note{gohar.NoteG, gohar.NoteA, gohar.NoteB, gohar.NoteC.Octave(1), gohar.NoteD.Octave(1)} {
+					s.Append(gohar.DurationSixteenth.Tuplet(5, 4), n)
+				}
+				s.Append(gohar.DurationQuarter, gohar.NoteE.Octave(1))
+				return Tune{Music: s}
+			},
+			"X:1\nM:2/4\nL:1/8\nK:C\n(3:2:5C D E F2 z | (5:4:5G/2 A/2 B/2 c/2 d/2 e2 |]\n",
+		},
+		{
+			"chords and line breaks",
+			func() Tune {
+				s := progression.Sequence(0, gohar.TimeSignature{Beats: 2, BeatType: 4})
+				s.Append(gohar.DurationWhole.Add(gohar.DurationQuarter))
+				s.Append(gohar.DurationHalf, gohar.NoteC, gohar.NoteE, gohar.NoteG)
+				return Tune{Music: s}
+			},
+			"X:1\nM:2/4\nL:1/8\nK:C\n" +
+				"\"Dm7\"[DFAc]2 \"G7\"[GBdf]2 | \"Cmaj7\"[CEGB]4 | z4 | z4 |\n" +
+				"z2 [CEG]2- | [CEG]2 |]\n",
+		},
+		{
+			"theoretical key",
+			func() Tune {
+				var s gohar.Sequence
+				s.Append(gohar.DurationHalf, gohar.NoteG.Sharp())
+				s.Append(gohar.DurationHalf, gohar.NoteA.Flat())
+				return Tune{Key: &gSharpMajor, Music: s}
+			},
+			"X:1\nM:4/4\nL:1/8\nK:Ab\n^G4 A4 |]\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := TuneToABC(tc.Tune())
+			Expect(t, NoError(err), Equal(tc.Want, got))
+		})
+	}
+}
+
+func TestTuneToABCInvalid(t *testing.T) {
+	overlapping := gohar.Sequence{Events: []gohar.Event{
+		{Notes: []gohar.Note{gohar.NoteC}, Duration: gohar.DurationHalf},
+		{Notes: []gohar.Note{gohar.NoteE}, Start: gohar.DurationQuarter, Duration: gohar.DurationHalf},
+	}}
+	var undecuplet gohar.Sequence
+	undecuplet.Append(gohar.DurationSixteenth.Tuplet(11, 8), gohar.NoteC)
+
+	testCases := []struct {
+		Name string
+		Tune Tune
+		Want error
+	}{
+		{"meter", Tune{Music: gohar.Sequence{TimeSignature: gohar.TimeSignature{Beats: 4, BeatType: 6}}}, gohar.ErrInvalidTimeSignature},
+		{"unit note length", Tune{UnitLength: gohar.NewDuration(-1, 8)}, ErrInvalidTune},
+		{"overlapping events", Tune{Music: overlapping}, gohar.ErrInvalidSequence},
+		{"tuplet", Tune{Music: undecuplet}, gohar.ErrInvalidSequence},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := TuneToABC(tc.Tune)
+			Expect(t, IsError(tc.Want, err))
+		})
+	}
+}
+
+func TestTuneRoundTrip(t *testing.T) {
+	progression, err := gohar.ParseProgression("| Am7 D7 | Gmaj7 | F#m7b5 B7b9 | Em |", 2)
+	Require(t, NoError(err))
+	key := gohar.NewMinorKey(gohar.PitchClassE)
+	s := progression.Sequence(-1, gohar.TimeSignature{Beats: 2, BeatType: 4})
+	s.Append(gohar.DurationEighth, gohar.NoteB.Octave(-1))
+	s.Append(gohar.DurationQuarter.Dotted(1), gohar.NoteD.Sharp())
+	s.Append(gohar.DurationQuarter)
+	s.Append(gohar.DurationHalf.Add(gohar.DurationEighth), gohar.NoteE, gohar.NoteG)
+	for _, n := range []gohar.Note{gohar.NoteF.Sharp(), gohar.NoteG, gohar.NoteA} {
+		s.Append(gohar.DurationSixteenth.Tuplet(3, 2), n)
+	}
+	tune := Tune{Number: 2, Title: "Turnaround", UnitLength: gohar.DurationEighth, Key: &key, Music: s}
+
+	abc, err := TuneToABC(tune)
+	Require(t, NoError(err))
+	got, err := ParseTune(abc)
+	Require(t, NoError(err))
+	Expect(t,
+		Equal(tune.Number, got.Number),
+		Equal(tune.Title, got.Title),
+		Equal(tune.UnitLength, got.UnitLength),
+		Equal(key, *got.Key),
+		Equal(s.TimeSignature, got.Music.TimeSignature),
+	)
+	// notes tied over bar lines are merged back when parsed
+	want, err := s.Voice()
+	Require(t, NoError(err))
+	want = want.MergeTies()
+	Expect(t, Equal(eventStrings(want), eventStrings(got.Music)))
+}
+
+// eventStrings returns the notes, times and chord symbols of the events.
+func eventStrings(s gohar.Sequence) []string {
+	var events []string
+	for _, e := range s.Events {
+		str := e.Start.String() + "+" + e.Duration.String()
+		for _, n := range e.Notes {
+			str += " " + n.String()
+		}
+		if e.Harmony != nil {
+			str += " " + e.Harmony.String()
+		}
+		events = append(events, str)
+	}
+	return events
+}
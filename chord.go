@@ -2,9 +2,137 @@ package gohar
 
 import (
 	"fmt"
+	"iter"
 	"math/bits"
 )
 
+// A Chord is the association of a root PitchClass and a ChordPattern.
+// Slash chords (e.g. C/G) additionally specify a Bass.
+type Chord struct {
+	// Root is the root note of the chord.
+	Root PitchClass
+	// Pattern is the chord's pattern.
+	Pattern ChordPattern
+	// Bass is the bass note of a slash chord.
+	// The zero value means that the chord has no explicit bass.
+	Bass PitchClass
+}
+
+// HasBass returns true if the chord is a slash chord, i.e. if it has a valid bass
+// that differs from its root.
+func (c Chord) HasBass() bool {
+	return c.Bass.IsValid() && c.Bass != c.Root
+}
+
+// String returns the chord symbol of the chord, e.g. "Cm7♭5/G".
+func (c Chord) String() string {
+	s := c.Root.String() + c.Pattern.Name()
+	if c.HasBass() {
+		s += "/" + c.Bass.String()
+	}
+	return s
+}
+
+// PitchClasses iterates over the pitch classes of the chord, starting with
+// its bass (if any), then its root, then the other chord tones in the order of
+// the chord pattern's intervals (see [ChordPattern.IntoIntervals]).
+func (c Chord) PitchClasses() iter.Seq[PitchClass] {
+	return func(yield func(PitchClass) bool) {
+		if c.HasBass() && !yield(c.Bass) {
+			return
+		}
+		for _, i := range c.Pattern.AsIntervals() {
+			pc := c.Root.Transpose(i)
+			if c.HasBass() && pc.IsEnharmonic(c.Bass) {
+				continue
+			}
+			if !yield(pc) {
+				return
+			}
+		}
+	}
+}
+
+// Notes iterates over the notes of the chord in ascending order, with the
+// root at given octave. Extensions are laid out in the octave above.
+// The bass of a slash chord is placed below the root, and isn't repeated above it.
+func (c Chord) Notes(oct int8) iter.Seq[Note] {
+	return func(yield func(Note) bool) {
+		root := Note{c.Root, oct}
+		if c.HasBass() {
+			bass := Note{c.Bass, oct}
+			if bass.Pitch() >= root.Pitch() {
+				bass.Oct--
+			}
+			if !yield(bass) {
+				return
+			}
+		}
+		for _, i := range c.Pattern.AsIntervals() {
+			note := root.Transpose(i)
+			if c.HasBass() && note.PitchClass.IsEnharmonic(c.Bass) {
+				continue
+			}
+			if !yield(note) {
+				return
+			}
+		}
+	}
+}
+
+// Pitches iterates over the pitches of the chord in ascending order, starting
+// from given root pitch. The layout is the same as in [Chord.Notes].
+func (c Chord) Pitches(root Pitch) iter.Seq[Pitch] {
+	return func(yield func(Pitch) bool) {
+		bass := Pitch(-1)
+		if c.HasBass() {
+			bass = c.Bass.Pitch(0)
+			pitch := c.Bass.Pitch(root.GetOctave())
+			if pitch >= root {
+				pitch -= PitchDiffOctave
+			}
+			if !yield(pitch) {
+				return
+			}
+		}
+		for _, i := range c.Pattern.AsIntervals() {
+			pitch := root + i.PitchDiff
+			if pitch.Normalize() == bass {
+				continue
+			}
+			if !yield(pitch) {
+				return
+			}
+		}
+	}
+}
+
+// Transpose transposes the chord (root and bass) by given interval.
+func (c Chord) Transpose(i Interval) Chord {
+	c.Root = c.Root.Transpose(i)
+	if c.HasBass() {
+		c.Bass = c.Bass.Transpose(i)
+	}
+	return c
+}
+
+// Inversion returns the n-th inversion of the chord, as a slash chord whose
+// bass is the n-th chord tone (0 being the root, 1 the third, and so on).
+// The 0-th inversion is the chord in root position, without a bass.
+//
+// ErrInvalidInversion is returned if n is not in the range [0;c.Pattern.CountNotes()-1].
+func (c Chord) Inversion(n int) (Chord, error) {
+	intervals := c.Pattern.AsIntervals()
+	if n < 0 || n >= len(intervals) {
+		return c, wrapErrorf(ErrInvalidInversion, "%d", n)
+	}
+	c.Bass = 0
+	if n > 0 {
+		c.Bass = c.Root.Transpose(intervals[n])
+	}
+	return c, nil
+}
+
 // A chord pattern is represented bitwise as a 24-bit number.
 // Each bit corresponds to a discrete pitch, within two 12-pitch octaves.
 // When unpacked, a chord is preferably laid out using the first octave for the
@@ -131,14 +259,17 @@ func (c ChordPattern) IntoIntervals(out []Interval) ([]Interval, error) {
 	}
 
 	// Irregular cases
-	if c.Contains(ChordPatternDiminished7) {
-		// Not a major 6th
-		chord[3] = IntDiminishedSeventh
-	}
-
-	// Altered chord: #9 -> b10
-	if c.Contains(ChordPattern7No5) && c.HasAllDegrees(PitchDiffAugmentedNinth, PitchDiffMinorThirteenth) {
-		chord[3] = IntMinorTenth
+	diminished7 := c.Contains(ChordPatternDiminished7)
+	altered := c.Contains(ChordPattern7No5) && c.HasAllDegrees(PitchDiffAugmentedNinth, PitchDiffMinorThirteenth)
+	for i, interval := range chord {
+		switch {
+		case diminished7 && interval == IntMajorSixth:
+			// Not a major 6th
+			chord[i] = IntDiminishedSeventh
+		case altered && interval == IntAugmentedNinth:
+			// Altered chord: #9 -> b10
+			chord[i] = IntMinorTenth
+		}
 	}
 	return chord, nil
 }
@@ -182,6 +313,19 @@ func (c ChordPattern) Unpack() ChordPattern {
 	return c
 }
 
+// FoldFlatThirteenth returns the pattern with its ♭13 turned back into a ♯5 if
+// it stands for an augmented fifth. Unpacking a seventh chord turns its ♯5
+// into a ♭13, which only suits altered dominants, that also have a fifth, a ♭9
+// or a ♯9.
+func (c ChordPattern) FoldFlatThirteenth() ChordPattern {
+	if c.HasDegree(PitchDiffMinorThirteenth) &&
+		c.HasAnyDegree(PitchDiffMinorSeventh, PitchDiffMajorSeventh) &&
+		!c.HasAnyDegree(PitchDiffPerfectFifth, PitchDiffDiminishedFifth, PitchDiffMinorNinth, PitchDiffAugmentedNinth) {
+		return c.Omit(PitchDiffMinorThirteenth).Add(PitchDiffAugmentedFifth)
+	}
+	return c
+}
+
 // move a degree up an octave (make it an extension)
 func (c ChordPattern) moveUp(degree Pitch) ChordPattern {
 	if c.HasDegree(degree) {
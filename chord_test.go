@@ -1,6 +1,7 @@
 package gohar
 
 import (
+	"slices"
 	"testing"
 
 	. "github.com/ArnaudCalmettes/gohar/test/helpers"
@@ -256,6 +257,30 @@ func BenchmarkChordPrintAsIntervalSliceInto(b *testing.B) {
 	}
 }
 
+func TestChordPrintFoldFlatThirteenth(t *testing.T) {
+	testCases := []struct {
+		Symbol string
+		Want   ChordPattern
+	}{
+		{Symbol: "C7#5", Want: ChordPattern7No5.Add(PitchDiffAugmentedFifth)},
+		{Symbol: "C9#5", Want: ChordPattern7No5.Add(PitchDiffAugmentedFifth).Add(PitchDiffMajorNinth)},
+		{Symbol: "Cmaj7#5", Want: ChordPatternMajor7No5.Add(PitchDiffAugmentedFifth)},
+		{Symbol: "C7alt", Want: ChordPattern7No5.Add(PitchDiffAugmentedNinth).Add(PitchDiffMinorThirteenth)},
+		{Symbol: "C7b9b13", Want: ChordPattern7.Add(PitchDiffMinorNinth).Add(PitchDiffMinorThirteenth)},
+		{Symbol: "Caug", Want: ChordPatternAugmented},
+	}
+
+	for _, tc := range testCases {
+		chord, err := ParseChord(tc.Symbol)
+		Require(t,
+			NoError(err),
+		)
+		Expect(t,
+			Equalf(tc.Want, chord.Pattern.Unpack().FoldFlatThirteenth(), "%s", tc.Symbol),
+		)
+	}
+}
+
 func TestSwap(t *testing.T) {
 	c := ChordPattern(0b101)
 	Expect(t,
@@ -263,3 +288,106 @@ func TestSwap(t *testing.T) {
 		Equal(ChordPattern(0b110), c.swap(0, 1)),
 	)
 }
+
+func TestChordString(t *testing.T) {
+	testCases := []struct {
+		Chord
+		Want string
+	}{
+		{Chord{Root: PitchClassC, Pattern: ChordPatternMajor}, "C"},
+		{Chord{Root: PitchClassC, Pattern: ChordPatternMinor7Flat5, Bass: PitchClassG}, "Cm7♭5/G"},
+		{Chord{Root: PitchClassF.Sharp(), Pattern: ChordPattern7}, "F♯7"},
+		{Chord{Root: PitchClassD, Pattern: ChordPatternMinor7, Bass: PitchClassD}, "Dm7"},
+	}
+	for _, tc := range testCases {
+		Expect(t, Equal(tc.Want, tc.Chord.String()))
+	}
+}
+
+func TestChordNotes(t *testing.T) {
+	t.Run("break", func(t *testing.T) {
+		count := 0
+		for n := range (Chord{Root: PitchClassC, Pattern: ChordPattern7}).Notes(0) {
+			if n != NoteC {
+				break
+			}
+			count++
+		}
+		Expect(t, Equal(1, count))
+	})
+
+	testCases := []struct {
+		Symbol string
+		Oct    int8
+		Want   []Note
+	}{
+		{"C", 0, []Note{NoteC, NoteE, NoteG}},
+		{"Co7", 0, []Note{NoteC, NoteE.Flat(), NoteG.Flat(), NoteB.DoubleFlat()}},
+		{"Ebm7", -1, []Note{
+			NoteE.Flat().Octave(-1), NoteG.Flat().Octave(-1),
+			NoteB.Flat().Octave(-1), NoteD.Flat(),
+		}},
+		{"C7alt", 0, []Note{NoteC, NoteE, NoteB.Flat(), NoteE.Flat().Octave(1), NoteA.Flat().Octave(1)}},
+		{"C7#9b13", 0, []Note{
+			NoteC, NoteE, NoteG, NoteB.Flat(),
+			NoteE.Flat().Octave(1), NoteA.Flat().Octave(1),
+		}},
+		{"Cmaj9", 0, []Note{NoteC, NoteE, NoteG, NoteB, NoteD.Octave(1)}},
+		{"C/E", 0, []Note{NoteE.Octave(-1), NoteC, NoteG}},
+		{"C/D", 0, []Note{NoteD.Octave(-1), NoteC, NoteE, NoteG}},
+	}
+	for _, tc := range testCases {
+		chord, err := ParseChord(tc.Symbol)
+		Require(t, NoError(err))
+		Expect(t,
+			Equalf(tc.Want, slices.Collect(chord.Notes(tc.Oct)), "%s", tc.Symbol),
+		)
+	}
+}
+
+func TestChordPitchClassesAndPitches(t *testing.T) {
+	chord := Chord{Root: PitchClassC, Pattern: ChordPatternMinor7, Bass: PitchClassB.Flat()}
+	Expect(t,
+		Equal(
+			[]PitchClass{PitchClassB.Flat(), PitchClassC, PitchClassE.Flat(), PitchClassG},
+			slices.Collect(chord.PitchClasses()),
+		),
+		Equal([]Pitch{10, 12, 15, 19}, slices.Collect(chord.Pitches(12))),
+		Equal([]Pitch{0, 3, 7, 10}, slices.Collect(Chord{Root: PitchClassC, Pattern: ChordPatternMinor7}.Pitches(0))),
+	)
+}
+
+func TestChordTranspose(t *testing.T) {
+	chord := Chord{Root: PitchClassC, Pattern: ChordPattern7, Bass: PitchClassE}
+	Expect(t,
+		Equal(
+			Chord{Root: PitchClassE.Flat(), Pattern: ChordPattern7, Bass: PitchClassG},
+			chord.Transpose(IntMinorThird),
+		),
+		Equal(
+			Chord{Root: PitchClassD.Flat(), Pattern: ChordPatternMajor},
+			Chord{Root: PitchClassG, Pattern: ChordPatternMajor}.Transpose(IntDiminishedFifth),
+		),
+	)
+}
+
+func TestChordInversion(t *testing.T) {
+	isChord := AsCheckFunc(func(want, got Chord) error { return Equal(want, got) })
+	isError := HasError[Chord]
+	c7 := Chord{Root: PitchClassC, Pattern: ChordPattern7, Bass: PitchClassG}
+
+	testCases := []struct {
+		N     int
+		Check CheckFunc[Chord]
+	}{
+		{-1, isError(ErrInvalidInversion)},
+		{4, isError(ErrInvalidInversion)},
+		{0, isChord(Chord{Root: PitchClassC, Pattern: ChordPattern7})},
+		{1, isChord(Chord{Root: PitchClassC, Pattern: ChordPattern7, Bass: PitchClassE})},
+		{3, isChord(Chord{Root: PitchClassC, Pattern: ChordPattern7, Bass: PitchClassB.Flat()})},
+	}
+	for _, tc := range testCases {
+		got, err := c7.Inversion(tc.N)
+		Expect(t, tc.Check(got, err))
+	}
+}
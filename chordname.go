@@ -0,0 +1,243 @@
+package gohar
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A ChordStyle describes the notation used to name chord qualities
+// in chord symbols.
+type ChordStyle struct {
+	// Major is written before the 7th (or the highest extension) of
+	// major seventh chords, e.g. "maj" in "Cmaj7".
+	Major string
+	// Minor is written after the root of minor chords, e.g. "m" in "Cm7".
+	Minor string
+	// Diminished is written after the root of diminished chords, e.g. "dim" in "Cdim7".
+	Diminished string
+	// HalfDiminished is written after the root of half-diminished chords, e.g. "ø" in "Cø7".
+	// If empty, half-diminished chords are written as minor chords with a flat fifth.
+	HalfDiminished string
+	// Augmented is written after the root of augmented triads, e.g. "aug" in "Caug".
+	Augmented string
+}
+
+var (
+	// ChordStyleStandard names chords like "Cmaj7", "Cm7♭5", "Cdim7" or "Caug".
+	ChordStyleStandard = ChordStyle{
+		Major:      "maj",
+		Minor:      "m",
+		Diminished: "dim",
+		Augmented:  "aug",
+	}
+
+	// ChordStyleJazz names chords like "CΔ7", "C-7", "Cø7", "C°7" or "C+".
+	ChordStyleJazz = ChordStyle{
+		Major:          "Δ",
+		Minor:          "-",
+		Diminished:     "°",
+		HalfDiminished: "ø",
+		Augmented:      "+",
+	}
+
+	// ChordStyleShort names chords like "CM7", "Cm7♭5", "Cdim7" or "C+".
+	ChordStyleShort = ChordStyle{
+		Major:      "M",
+		Minor:      "m",
+		Diminished: "dim",
+		Augmented:  "+",
+	}
+)
+
+// Name returns the conventional chord symbol suffix of the chord pattern
+// in the standard style, e.g. "m7♭5" or "7♯9♭13".
+func (c ChordPattern) Name() string {
+	return ChordStyleStandard.Name(c)
+}
+
+// Name returns the conventional chord symbol suffix of the chord pattern
+// in the current style (e.g. "m7♭5", "7♯9♭13", "maj7♯11", "6/9" or "sus4 add9").
+//
+// The chord pattern is unpacked before being named, so that its extensions
+// are laid out the same way as with [ChordPattern.Unpack].
+func (s ChordStyle) Name(c ChordPattern) string {
+	n := chordNamer{ChordStyle: s, chord: c.Unpack()}
+	n.rest = n.chord.Omit(PitchDiffUnisson)
+	return n.name()
+}
+
+type chordNamer struct {
+	ChordStyle
+	chord ChordPattern
+	rest  ChordPattern // degrees that haven't been named yet
+	b     strings.Builder
+}
+
+func (n *chordNamer) has(p Pitch) bool {
+	return n.chord.HasDegree(p)
+}
+
+func (n *chordNamer) take(pitches ...Pitch) {
+	for _, p := range pitches {
+		n.rest = n.rest.Omit(p)
+	}
+}
+
+func (n *chordNamer) name() string {
+	if n.chord == ChordPattern(0).Add(PitchDiffUnisson).Add(PitchDiffPerfectFifth) {
+		return "5"
+	}
+	var (
+		minorThird     = n.has(PitchDiffMinorThird)
+		majorThird     = n.has(PitchDiffMajorThird)
+		flatFifth      = n.has(PitchDiffDiminishedFifth)
+		fifth          = n.has(PitchDiffPerfectFifth)
+		minorSeventh   = n.has(PitchDiffMinorSeventh)
+		majorSeventh   = n.has(PitchDiffMajorSeventh)
+		seventh        = minorSeventh || majorSeventh
+		diminished     = minorThird && flatFifth && !fifth
+		diminished7    = diminished && !seventh && n.has(PitchDiffDiminishedSeventh)
+		halfDiminished = diminished && minorSeventh && n.HalfDiminished != ""
+	)
+	n.take(PitchDiffMinorThird, PitchDiffMajorThird, PitchDiffPerfectFifth)
+
+	// Unpack turns a #5 into a b13 in the presence of a 7th. We call it a #5
+	// back, unless the chord is an altered dominant.
+	if n.chord.FoldFlatThirteenth() != n.chord {
+		n.take(PitchDiffMinorThirteenth)
+		n.rest = n.rest.Add(PitchDiffAugmentedFifth)
+	}
+
+	switch {
+	case diminished7:
+		n.b.WriteString(n.Diminished + "7")
+		n.take(PitchDiffDiminishedFifth, PitchDiffDiminishedSeventh)
+	case halfDiminished:
+		n.b.WriteString(n.HalfDiminished)
+		n.take(PitchDiffDiminishedFifth)
+	case diminished && !seventh:
+		n.b.WriteString(n.Diminished)
+		n.take(PitchDiffDiminishedFifth)
+	case majorThird && !fifth && !seventh && n.has(PitchDiffAugmentedFifth):
+		n.b.WriteString(n.Augmented)
+		n.take(PitchDiffAugmentedFifth)
+	case minorThird:
+		n.b.WriteString(n.Minor)
+	}
+
+	switch {
+	case seventh:
+		if majorSeventh {
+			n.b.WriteString(n.Major)
+		}
+		n.b.WriteString(strconv.Itoa(n.highestExtension(minorThird)))
+		n.take(PitchDiffMinorSeventh, PitchDiffMajorSeventh)
+	case !diminished7 && n.has(PitchDiffMajorSixth):
+		n.b.WriteString("6")
+		n.take(PitchDiffMajorSixth)
+		if n.has(PitchDiffMajorNinth) {
+			n.b.WriteString("/9")
+			n.take(PitchDiffMajorNinth)
+		}
+	}
+
+	var noThird bool
+	if !minorThird && !majorThird {
+		switch {
+		case n.has(PitchDiffPerfectFourth):
+			n.b.WriteString("sus4")
+			n.take(PitchDiffPerfectFourth)
+		case n.has(PitchDiffMajorSecond):
+			n.b.WriteString("sus2")
+			n.take(PitchDiffMajorSecond)
+		default:
+			noThird = true
+		}
+	}
+
+	// Name the remaining degrees using the same sieve as IntoIntervals:
+	// alterations are appended, natural degrees are added. Alterations that
+	// would follow the root or the quality are written in parentheses, since
+	// "C♭5" would read as a C♭ power chord.
+	var paren bool
+	for _, interval := range sieve {
+		if !n.rest.HasDegree(interval.PitchDiff) {
+			continue
+		}
+		degree, alt := chordDegreeName(interval)
+		switch {
+		case alt == 0:
+			n.closeParen(&paren)
+			n.add("add" + degree)
+		case !paren && n.endsWithLetter():
+			paren = true
+			n.b.WriteString("(" + altToString(alt) + degree)
+		default:
+			n.b.WriteString(altToString(alt) + degree)
+		}
+	}
+	n.closeParen(&paren)
+	if noThird {
+		n.add("no3")
+	}
+	return n.b.String()
+}
+
+// highestExtension returns the highest natural extension (7, 9, 11 or 13)
+// that can be used to name a seventh chord. 11th and 13th chords may have an
+// altered 9th instead, which is left to be named as an alteration.
+func (n *chordNamer) highestExtension(minor bool) int {
+	ninth := n.has(PitchDiffMajorNinth)
+	if !ninth && !n.has(PitchDiffMinorNinth) && !n.has(PitchDiffAugmentedNinth) {
+		return 7
+	}
+	n.take(PitchDiffMajorNinth)
+	switch {
+	case n.has(PitchDiffMajorThirteenth):
+		n.take(PitchDiffMajorThirteenth)
+		if minor {
+			// the 11th is implied in minor 13th chords
+			n.take(PitchDiffPerfectEleventh)
+		}
+		return 13
+	case n.has(PitchDiffPerfectEleventh):
+		n.take(PitchDiffPerfectEleventh)
+		return 11
+	case !ninth:
+		return 7
+	}
+	return 9
+}
+
+// endsWithLetter returns true if the name is empty or ends with a letter, such
+// as "m" or "dim".
+func (n *chordNamer) endsWithLetter() bool {
+	r, _ := utf8.DecodeLastRuneInString(n.b.String())
+	return r == utf8.RuneError || unicode.IsLetter(r)
+}
+
+// closeParen closes the parenthesis around alterations, if open.
+func (n *chordNamer) closeParen(open *bool) {
+	if *open {
+		n.b.WriteByte(')')
+		*open = false
+	}
+}
+
+// add appends a space-separated token to the name.
+func (n *chordNamer) add(token string) {
+	if n.b.Len() > 0 {
+		n.b.WriteByte(' ')
+	}
+	n.b.WriteString(token)
+}
+
+// chordDegreeName returns the degree number of an interval as used in chord symbols,
+// and its alteration relative to the major or perfect interval with the same number.
+func chordDegreeName(i Interval) (string, Pitch) {
+	oct := Pitch(i.ScaleDiff / 7)
+	natural := asPitch[i.ScaleDiff%7] + oct*PitchDiffOctave
+	return strconv.Itoa(int(i.ScaleDiff) + 1), i.PitchDiff - natural
+}
@@ -0,0 +1,106 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestChordPatternName(t *testing.T) {
+	testCases := []struct {
+		Chord ChordPattern
+		Want  string
+	}{
+		{ChordPatternMajor, ""},
+		{ChordPatternMinor, "m"},
+		{ChordPatternDiminished, "dim"},
+		{ChordPatternAugmented, "aug"},
+		{ChordPatternSus4, "sus4"},
+		{ChordPatternMajor7, "maj7"},
+		{ChordPatternMajor7No5, "maj7"},
+		{ChordPattern7, "7"},
+		{ChordPatternMinor7, "m7"},
+		{ChordPatternMinor7Flat5, "m7♭5"},
+		{ChordPatternDiminished7, "dim7"},
+		{0b10000001, "5"},
+		{ChordPatternMinor.Add(PitchDiffMajorSeventh), "mmaj7"},
+		{ChordPatternMajor.Add(PitchDiffMajorSixth), "6"},
+		{ChordPatternMajor.Add(PitchDiffMajorSixth).Add(PitchDiffMajorSecond), "6/9"},
+		{ChordPatternMajor.Add(PitchDiffMajorSecond), "add9"},
+		{ChordPatternMinor.Add(PitchDiffMajorSecond), "m add9"},
+		{ChordPatternSus4.Add(PitchDiffMajorSecond), "sus4 add9"},
+		{ChordPatternSus4.Add(PitchDiffMinorSeventh).Add(PitchDiffMajorSecond), "9sus4"},
+		{ChordPatternMajor.Omit(PitchDiffMajorThird).Add(PitchDiffMajorSecond), "sus2"},
+		{ChordPattern7.Add(PitchDiffMajorSecond), "9"},
+		{ChordPattern7.Add(PitchDiffMajorSecond).Add(PitchDiffMajorSixth), "13"},
+		{ChordPatternMinor7.Add(PitchDiffMajorSecond).Add(PitchDiffPerfectFourth), "m11"},
+		{ChordPatternMinor7.Add(PitchDiffMajorSecond).Add(PitchDiffPerfectFourth).Add(PitchDiffMajorSixth), "m13"},
+		{ChordPattern7.Add(PitchDiffMinorSecond), "7♭9"},
+		{ChordPattern7.Add(PitchDiffMajorSixth).Add(PitchDiffMinorSecond), "13♭9"},
+		{ChordPattern7.Add(PitchDiffMajorSixth).Add(PitchDiffMinorThird), "13♯9"},
+		{ChordPattern7.Add(PitchDiffPerfectFourth).Add(PitchDiffMinorSecond), "11♭9"},
+		{ChordPattern7No5.Add(PitchDiffMinorThird).Add(PitchDiffAugmentedFifth), "7♯9♭13"},
+		{ChordPattern7No5.Add(PitchDiffAugmentedFifth), "7♯5"},
+		{ChordPattern7No5.Add(PitchDiffDiminishedFifth), "7♭5"},
+		{ChordPatternMajor7.Add(PitchDiffAugmentedFourth), "maj7♯11"},
+		{ChordPatternMajor7.Add(PitchDiffMajorSecond).Add(PitchDiffAugmentedFourth), "maj9♯11"},
+		{ChordPatternMajor7No5.Add(PitchDiffAugmentedFifth), "maj7♯5"},
+		{ChordPatternDiminished7.Add(PitchDiffMajorSeventh), "dim7 add14"},
+		{ChordPatternMajor.Add(PitchDiffAugmentedFourth), "(♯11)"},
+		{ChordPatternMajor.Omit(PitchDiffPerfectFifth).Add(PitchDiffDiminishedFifth), "(♭5)"},
+		{ChordPatternMajor.Add(PitchDiffMinorSecond).Add(PitchDiffAugmentedFourth), "(♭9♯11)"},
+		{ChordPatternMinor.Add(PitchDiffMinorSecond).Add(PitchDiffPerfectFourth), "m(♭9) add11"},
+		{ChordPattern7No5.Omit(PitchDiffMajorThird), "7 no3"},
+	}
+
+	for _, tc := range testCases {
+		Expect(t, Equalf(tc.Want, tc.Chord.Name(), "%012b", tc.Chord))
+	}
+}
+
+func TestChordStyleName(t *testing.T) {
+	testCases := []struct {
+		Chord    ChordPattern
+		Standard string
+		Jazz     string
+		Short    string
+	}{
+		{ChordPatternMajor7, "maj7", "Δ7", "M7"},
+		{ChordPatternMinor7, "m7", "-7", "m7"},
+		{ChordPatternMinor7Flat5, "m7♭5", "ø7", "m7♭5"},
+		{ChordPatternMinor7Flat5.Add(PitchDiffMajorSecond), "m9♭5", "ø9", "m9♭5"},
+		{ChordPatternDiminished, "dim", "°", "dim"},
+		{ChordPatternDiminished7, "dim7", "°7", "dim7"},
+		{ChordPatternAugmented, "aug", "+", "+"},
+		{ChordPatternMinor.Add(PitchDiffMajorSeventh), "mmaj7", "-Δ7", "mM7"},
+	}
+
+	for _, tc := range testCases {
+		Expect(t,
+			Equal(tc.Standard, ChordStyleStandard.Name(tc.Chord)),
+			Equal(tc.Jazz, ChordStyleJazz.Name(tc.Chord)),
+			Equal(tc.Short, ChordStyleShort.Name(tc.Chord)),
+		)
+	}
+}
+
+func TestChordNameParseRoundTrip(t *testing.T) {
+	symbols := []string{
+		"C", "Cm", "C7", "Cmaj7", "Cm7b5", "Co7", "C+", "Csus4", "C6/9",
+		"Cmaj9#11", "C7alt", "C13b9", "C9sus4", "CmMaj7", "C7#5", "Cm11",
+		"C(#11)", "Caddb9", "Cadd#9", "C(b5)", "Cm(b9)", "Co(b13)", "C13#9",
+	}
+	styles := []ChordStyle{ChordStyleStandard, ChordStyleJazz, ChordStyleShort}
+	for _, symbol := range symbols {
+		chord, err := ParseChord(symbol)
+		Require(t, NoErrorf(err, "parse %q", symbol))
+		for _, style := range styles {
+			name := style.Name(chord.Pattern)
+			parsed, err := ParseChord("C" + name)
+			Expect(t,
+				NoErrorf(err, "parse %q (from %q)", name, symbol),
+				Equalf(name, style.Name(parsed.Pattern), "%q -> %q", symbol, name),
+			)
+		}
+	}
+}
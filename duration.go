@@ -0,0 +1,199 @@
+package gohar
+
+import "fmt"
+
+// A Duration is an exact span of musical time, as a fraction of a whole note.
+// It is also used for positions in time, relative to the start of the music.
+//
+// The zero Duration is null.
+type Duration struct {
+	num, den int
+}
+
+// Common durations.
+var (
+	DurationWhole        = NewDuration(1, 1)
+	DurationHalf         = NewDuration(1, 2)
+	DurationQuarter      = NewDuration(1, 4)
+	DurationEighth       = NewDuration(1, 8)
+	DurationSixteenth    = NewDuration(1, 16)
+	DurationThirtySecond = NewDuration(1, 32)
+)
+
+// NewDuration returns the duration num/den of a whole note, such as
+// NewDuration(3, 8) for a dotted quarter note.
+//
+// This function panics if den is zero.
+func NewDuration(num, den int) Duration {
+	if den == 0 {
+		panic("gohar: duration with a zero denominator")
+	}
+	if den < 0 {
+		num, den = -num, -den
+	}
+	g := gcd(max(num, -num), den)
+	return Duration{num / g, den / g}
+}
+
+// norm returns the duration with a valid denominator.
+func (d Duration) norm() Duration {
+	if d.den == 0 {
+		return Duration{0, 1}
+	}
+	return d
+}
+
+// Num returns the numerator of the reduced fraction.
+func (d Duration) Num() int {
+	return d.num
+}
+
+// Den returns the denominator of the reduced fraction, which is always positive.
+func (d Duration) Den() int {
+	return d.norm().den
+}
+
+// IsZero returns true if the duration is null.
+func (d Duration) IsZero() bool {
+	return d.num == 0
+}
+
+// Add returns the sum of both durations.
+func (d Duration) Add(o Duration) Duration {
+	d, o = d.norm(), o.norm()
+	return NewDuration(d.num*o.den+o.num*d.den, d.den*o.den)
+}
+
+// Sub returns the difference of both durations.
+func (d Duration) Sub(o Duration) Duration {
+	d, o = d.norm(), o.norm()
+	return NewDuration(d.num*o.den-o.num*d.den, d.den*o.den)
+}
+
+// Scale returns the duration multiplied by num/den.
+//
+// This method panics if den is zero.
+func (d Duration) Scale(num, den int) Duration {
+	d = d.norm()
+	return NewDuration(d.num*num, d.den*den)
+}
+
+// Div returns the ratio of both durations, as a reduced fraction.
+//
+// This method panics if o is null.
+func (d Duration) Div(o Duration) (num, den int) {
+	d, o = d.norm(), o.norm()
+	r := NewDuration(d.num*o.den, d.den*o.num)
+	return r.num, r.den
+}
+
+// Count returns how many whole times o fits in the duration, rounded down, and
+// what is left.
+//
+// This method panics if o isn't positive.
+func (d Duration) Count(o Duration) (int, Duration) {
+	if o.num <= 0 {
+		panic("gohar: count of a non-positive duration")
+	}
+	num, den := d.Div(o)
+	n := num / den
+	if num%den < 0 {
+		n--
+	}
+	return n, d.Sub(o.Scale(n, 1))
+}
+
+// Dotted returns the duration with given number of dots: each dot adds half of
+// the value added by the previous one.
+func (d Duration) Dotted(dots int) Duration {
+	p := 1 << max(dots, 0)
+	return d.Scale(2*p-1, p)
+}
+
+// Tuplet returns the duration of a note in a tuplet where n notes are played in
+// the time of m, such as DurationEighth.Tuplet(3, 2) for a triplet eighth note.
+//
+// This method panics if n is zero.
+func (d Duration) Tuplet(n, m int) Duration {
+	return d.Scale(m, n)
+}
+
+// Undotted returns the value of the undotted note that lasts the duration once
+// given number of dots are added, such as a quarter note and one dot for 3/8.
+// It returns false if no single note lasts the duration, such as for 5/8 or for
+// the notes of tuplets.
+func (d Duration) Undotted() (Duration, int, bool) {
+	d = d.norm()
+	if d.num <= 0 || !isPowerOf2(d.den) || !isPowerOf2(d.num+1) {
+		return Duration{}, 0, false
+	}
+	var dots int
+	for n := d.num + 1; n > 2; n /= 2 {
+		dots++
+	}
+	return NewDuration((d.num+1)/2, d.den), dots, true
+}
+
+// NoteValues splits the duration into the values of single notes, dotted once
+// at most and no longer than a dotted whole note, from the longest to the
+// shortest: 5/8 gives a half note and an eighth note. Durations that aren't
+// made of such values, such as those of tuplets, are returned as is.
+func (d Duration) NoteValues() []Duration {
+	d = d.norm()
+	if d.num <= 0 || !isPowerOf2(d.den) {
+		return []Duration{d}
+	}
+	var values []Duration
+	for d.num > 0 {
+		// the longest power of 2, dotted if possible
+		v := Duration{1, d.den}
+		for v.num*2 <= d.num && v.num*2 <= d.den {
+			v.num *= 2
+		}
+		if v.num > 1 && v.num+v.num/2 <= d.num {
+			v.num += v.num / 2
+		}
+		values = append(values, NewDuration(v.num, v.den))
+		d.num -= v.num
+	}
+	return values
+}
+
+func isPowerOf2(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// Compare returns -1, 0 or +1 if the duration is shorter than, as long as, or
+// longer than the other.
+func (d Duration) Compare(o Duration) int {
+	d, o = d.norm(), o.norm()
+	a, b := d.num*o.den, o.num*d.den
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Equal returns true if both durations are the same.
+func (d Duration) Equal(o Duration) bool {
+	return d.Compare(o) == 0
+}
+
+// Float64 returns the duration in whole notes, as a floating-point number.
+func (d Duration) Float64() float64 {
+	d = d.norm()
+	return float64(d.num) / float64(d.den)
+}
+
+// String returns the duration as a fraction of a whole note, such as "3/8", or
+// as a whole number of whole notes, such as "2".
+func (d Duration) String() string {
+	d = d.norm()
+	if d.den == 1 {
+		return fmt.Sprint(d.num)
+	}
+	return fmt.Sprintf("%d/%d", d.num, d.den)
+}
@@ -0,0 +1,120 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestDuration(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Duration Duration
+		Want     string
+	}{
+		{"zero", Duration{}, "0"},
+		{"whole", DurationWhole, "1"},
+		{"reduced", NewDuration(4, 8), "1/2"},
+		{"negative denominator", NewDuration(1, -4), "-1/4"},
+		{"breve", DurationHalf.Scale(4, 1), "2"},
+		{"dotted quarter", DurationQuarter.Dotted(1), "3/8"},
+		{"double dotted half", DurationHalf.Dotted(2), "7/8"},
+		{"no dot", DurationEighth.Dotted(0), "1/8"},
+		{"triplet eighth", DurationEighth.Tuplet(3, 2), "1/12"},
+		{"quintuplet sixteenth", DurationSixteenth.Tuplet(5, 4), "1/20"},
+		{"sum", DurationQuarter.Add(DurationEighth.Tuplet(3, 2)), "1/3"},
+		{"difference", DurationThirtySecond.Sub(DurationEighth), "-3/32"},
+		{"zero sum", Duration{}.Add(DurationHalf), "1/2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			Expect(t, Equal(tc.Want, tc.Duration.String()))
+		})
+	}
+
+	Expect(t,
+		Equal(0, Duration{}.Compare(NewDuration(0, 3))),
+		Equal(-1, DurationEighth.Dotted(1).Compare(DurationQuarter)),
+		Equal(1, DurationEighth.Compare(DurationEighth.Tuplet(3, 2))),
+		IsTrue(DurationHalf.Equal(DurationQuarter.Scale(2, 1))),
+		IsTrue(Duration{}.IsZero()),
+		Equal(1, Duration{}.Den()),
+		Equal(0.375, DurationQuarter.Dotted(1).Float64()),
+		ShouldPanic(func() { NewDuration(1, 0) }),
+	)
+
+	num, den := DurationHalf.Div(DurationEighth.Tuplet(3, 2))
+	Expect(t, Equal(6, num), Equal(1, den))
+}
+
+func TestDurationCount(t *testing.T) {
+	testCases := []struct {
+		Duration, Unit Duration
+		Count          int
+		Left           Duration
+	}{
+		{NewDuration(7, 8), DurationQuarter, 3, DurationEighth},
+		{DurationHalf, DurationQuarter, 2, Duration{}},
+		{DurationEighth, DurationHalf, 0, DurationEighth},
+		{NewDuration(-1, 8), DurationQuarter, -1, DurationEighth},
+		{DurationWhole, DurationQuarter.Dotted(1), 2, DurationQuarter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Duration.String()+"/"+tc.Unit.String(), func(t *testing.T) {
+			count, left := tc.Duration.Count(tc.Unit)
+			Expect(t, Equal(tc.Count, count), Equal(tc.Left, left))
+		})
+	}
+
+	Expect(t, ShouldPanic(func() { DurationWhole.Count(Duration{}) }))
+}
+
+func TestDurationUndotted(t *testing.T) {
+	testCases := []struct {
+		Duration Duration
+		Value    Duration
+		Dots     int
+		OK       bool
+	}{
+		{DurationQuarter, DurationQuarter, 0, true},
+		{NewDuration(3, 8), DurationQuarter, 1, true},
+		{NewDuration(7, 8), DurationHalf, 2, true},
+		{NewDuration(3, 2), DurationWhole, 1, true},
+		{NewDuration(5, 8), Duration{}, 0, false},
+		{DurationEighth.Tuplet(3, 2), Duration{}, 0, false},
+		{Duration{}, Duration{}, 0, false},
+		{NewDuration(-1, 4), Duration{}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Duration.String(), func(t *testing.T) {
+			value, dots, ok := tc.Duration.Undotted()
+			Expect(t, Equal(tc.Value, value), Equal(tc.Dots, dots), Equal(tc.OK, ok))
+		})
+	}
+}
+
+func TestDurationNoteValues(t *testing.T) {
+	testCases := []struct {
+		Duration Duration
+		Want     []Duration
+	}{
+		{DurationQuarter, []Duration{DurationQuarter}},
+		{NewDuration(3, 8), []Duration{NewDuration(3, 8)}},
+		{NewDuration(5, 8), []Duration{DurationHalf, DurationEighth}},
+		{NewDuration(7, 8), []Duration{NewDuration(3, 4), DurationEighth}},
+		{NewDuration(5, 4), []Duration{DurationWhole, DurationQuarter}},
+		{NewDuration(2, 1), []Duration{DurationWhole, DurationWhole}},
+		{NewDuration(7, 4), []Duration{NewDuration(3, 2), DurationQuarter}},
+		{NewDuration(9, 16), []Duration{DurationHalf, DurationSixteenth}},
+		{DurationEighth.Tuplet(3, 2), []Duration{DurationEighth.Tuplet(3, 2)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Duration.String(), func(t *testing.T) {
+			Expect(t, Equal(tc.Want, tc.Duration.NoteValues()))
+		})
+	}
+}
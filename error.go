@@ -8,12 +8,24 @@ import (
 )
 
 var (
-	ErrBufferOverflow      = errors.New("buffer overflow")
-	ErrNilBuffer           = errors.New("nil buffer")
-	ErrInvalidPitchClass   = errors.New("invalid pitch class")
-	ErrInvalidAlteration   = errors.New("invalid alteration")
-	ErrUnknownScalePattern = errors.New("unknown scale pattern")
-	ErrInvalidDegree       = errors.New("invalid degree")
+	ErrBufferOverflow       = errors.New("buffer overflow")
+	ErrNilBuffer            = errors.New("nil buffer")
+	ErrInvalidPitchClass    = errors.New("invalid pitch class")
+	ErrInvalidAlteration    = errors.New("invalid alteration")
+	ErrUnknownScalePattern  = errors.New("unknown scale pattern")
+	ErrInvalidDegree        = errors.New("invalid degree")
+	ErrInvalidChordSymbol   = errors.New("invalid chord symbol")
+	ErrInvalidInversion     = errors.New("invalid inversion")
+	ErrInvalidInterval      = errors.New("invalid interval")
+	ErrInvalidScalePattern  = errors.New("invalid scale pattern")
+	ErrInvalidRomanNumeral  = errors.New("invalid roman numeral")
+	ErrInvalidKey           = errors.New("invalid key")
+	ErrInvalidVoicing       = errors.New("invalid voicing")
+	ErrNoVoicing            = errors.New("no voicing")
+	ErrInvalidProgression   = errors.New("invalid progression")
+	ErrInvalidMIDINote      = errors.New("invalid MIDI note")
+	ErrInvalidTimeSignature = errors.New("invalid time signature")
+	ErrInvalidSequence      = errors.New("invalid sequence")
 )
 
 func checkOutputBuffer[T any](buffer []T, capacity int) error {
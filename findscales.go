@@ -0,0 +1,116 @@
+package gohar
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+)
+
+// FindScalesOptions tune the search carried out by [FindScales].
+type FindScalesOptions struct {
+	// Root is the preferred root of the scales (e.g. the root of a chord).
+	// When valid, scales built on this root are ranked first.
+	Root PitchClass
+	// RootOnly restricts the results to the scales built on Root.
+	RootOnly bool
+	// Patterns are the scale patterns to look into. If nil, all the known
+	// scale patterns are considered (see [ScalePatterns]).
+	Patterns []ScalePattern
+	// MaxMissing is the number of pitch classes that are allowed not to fit
+	// within the scales.
+	MaxMissing int
+}
+
+// FindScales returns the scales that contain the given pitch classes, ranked
+// from the best to the worst fit: scales that contain more of the pitch classes
+// come first, then the scales built on opts.Root, then the order of the catalog.
+//
+// Scale roots are spelled after opts.Root or the given pitch classes if possible.
+func FindScales(pcs []PitchClass, opts FindScalesOptions) []Scale {
+	var mask ScalePattern
+	for _, pc := range pcs {
+		if pc.IsValid() {
+			mask |= 1 << pc.Pitch(0).Normalize()
+		}
+	}
+	if mask == 0 {
+		return nil
+	}
+	patterns := opts.Patterns
+	if patterns == nil {
+		patterns = scaleCatalog
+	}
+
+	type match struct {
+		Scale
+		missing int
+		rooted  bool
+	}
+	var matches []match
+	for root := range Pitch(12) {
+		rooted := opts.Root.IsValid() && opts.Root.Pitch(0).Normalize() == root
+		if opts.RootOnly && !rooted {
+			continue
+		}
+		relative := rotatePattern(mask, int(root))
+		for _, pattern := range patterns {
+			missing := bits.OnesCount16(uint16(relative &^ pattern))
+			if missing > opts.MaxMissing {
+				continue
+			}
+			matches = append(matches, match{
+				Scale:   Scale{Root: spellScaleRoot(root, opts.Root, pcs), Pattern: pattern},
+				missing: missing,
+				rooted:  rooted,
+			})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int {
+		if c := cmp.Compare(a.missing, b.missing); c != 0 {
+			return c
+		}
+		switch {
+		case a.rooted && !b.rooted:
+			return -1
+		case b.rooted && !a.rooted:
+			return 1
+		}
+		return 0
+	})
+
+	scales := make([]Scale, len(matches))
+	for i, m := range matches {
+		scales[i] = m.Scale
+	}
+	return scales
+}
+
+// FindScalesForChord returns the scales that contain the chord, ranked as with
+// [FindScales]. Unless opts.Root is set, scales built on the root of the chord
+// are preferred.
+//
+// To look for the scales that fit a ChordPattern, use a Chord rooted on C.
+func FindScalesForChord(chord Chord, opts FindScalesOptions) []Scale {
+	if !opts.Root.IsValid() {
+		opts.Root = chord.Root
+	}
+	return FindScales(slices.Collect(chord.PitchClasses()), opts)
+}
+
+// rotatePattern transposes a set of pitches so that it's relative to given root.
+func rotatePattern(s ScalePattern, root int) ScalePattern {
+	const mask = 0b0000111111111111 // 12 lowest bits
+	return (s>>root | s<<(12-root)) & mask
+}
+
+func spellScaleRoot(root Pitch, preferred PitchClass, pcs []PitchClass) PitchClass {
+	if preferred.IsValid() && preferred.Pitch(0).Normalize() == root {
+		return preferred
+	}
+	for _, pc := range pcs {
+		if pc.IsValid() && pc.Pitch(0).Normalize() == root {
+			return pc
+		}
+	}
+	return DefaultPitchClass(root)
+}
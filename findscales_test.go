@@ -0,0 +1,92 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestFindScales(t *testing.T) {
+	Expect(t, IsEmptySlice(FindScales(nil, FindScalesOptions{})))
+
+	t.Run("white keys", func(t *testing.T) {
+		pcs := []PitchClass{
+			PitchClassC, PitchClassD, PitchClassE, PitchClassF,
+			PitchClassG, PitchClassA, PitchClassB,
+		}
+		scales := FindScales(pcs, FindScalesOptions{Patterns: []ScalePattern{ScalePatternMajor}})
+		Expect(t, Equal([]Scale{{PitchClassC, ScalePatternMajor}}, scales))
+
+		scales = FindScales(pcs, FindScalesOptions{Root: PitchClassD})
+		Require(t, IsNotEmptySlice(scales))
+		Expect(t, Equal(Scale{PitchClassD, ScalePatternDorian}, scales[0]))
+	})
+
+	t.Run("spelling", func(t *testing.T) {
+		pcs := []PitchClass{PitchClassF.Sharp(), PitchClassG.Sharp(), PitchClassC.Sharp()}
+		scales := FindScales(pcs, FindScalesOptions{
+			Patterns: []ScalePattern{ScalePatternMajorPentatonic},
+		})
+		Expect(t,
+			SliceContains(Scale{PitchClassF.Sharp(), ScalePatternMajorPentatonic}, scales),
+			SliceContains(Scale{PitchClassB, ScalePatternMajorPentatonic}, scales),
+			SliceContains(Scale{PitchClassE, ScalePatternMajorPentatonic}, scales),
+			SliceHasLength(3, scales),
+		)
+	})
+
+	t.Run("missing notes", func(t *testing.T) {
+		pcs := []PitchClass{PitchClassC, PitchClassE, PitchClassG, PitchClassB.Flat()}
+		opts := FindScalesOptions{
+			Root:     PitchClassC,
+			RootOnly: true,
+			Patterns: []ScalePattern{ScalePatternMajor, ScalePatternMixolydian},
+		}
+		Expect(t, Equal([]Scale{{PitchClassC, ScalePatternMixolydian}}, FindScales(pcs, opts)))
+
+		opts.MaxMissing = 1
+		Expect(t,
+			Equal(
+				[]Scale{{PitchClassC, ScalePatternMixolydian}, {PitchClassC, ScalePatternMajor}},
+				FindScales(pcs, opts),
+			),
+		)
+	})
+}
+
+func TestFindScalesForChord(t *testing.T) {
+	testCases := []struct {
+		Chord
+		Want Scale
+	}{
+		{
+			Chord{Root: PitchClassC, Pattern: ChordPatternMajor7},
+			Scale{PitchClassC, ScalePatternMajor},
+		}, {
+			Chord{Root: PitchClassD, Pattern: ChordPatternMinor7},
+			Scale{PitchClassD, ScalePatternDorian},
+		}, {
+			Chord{Root: PitchClassB, Pattern: ChordPatternMinor7Flat5},
+			Scale{PitchClassB, ScalePatternLocrian},
+		}, {
+			Chord{Root: PitchClassG, Pattern: ChordPattern7.Add(PitchDiffMinorNinth).Omit(PitchDiffPerfectFifth)},
+			Scale{PitchClassG, ScalePatternPhrygianDominant},
+		}, {
+			Chord{
+				Root: PitchClassG,
+				Pattern: ChordPattern7.Omit(PitchDiffPerfectFifth).
+					Add(PitchDiffAugmentedNinth).Add(PitchDiffMinorThirteenth),
+			},
+			Scale{PitchClassG, ScalePatternAltered},
+		},
+	}
+
+	for _, tc := range testCases {
+		scales := FindScalesForChord(tc.Chord, FindScalesOptions{})
+		Require(t, IsNotEmptySlice(scales))
+		Expect(t,
+			Equalf(tc.Want.Root, scales[0].Root, "%s", tc.Chord),
+			SliceContains(tc.Want, scales),
+		)
+	}
+}
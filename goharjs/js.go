@@ -25,16 +25,19 @@ func ImportBindings() {
 		"scalePatternName":    js.FuncOf(ScalePatternName),
 		"scalePatternPitches": js.FuncOf(ScalePatternPitches),
 		"scaleToABC":          js.FuncOf(ScaleToABC),
-		"scalePatterns": js.ValueOf([]any{
-			int(gohar.ScalePatternMajor),
-			int(gohar.ScalePatternMelodicMinor),
-			int(gohar.ScalePatternHarmonicMinor),
-			int(gohar.ScalePatternHarmonicMajor),
-			int(gohar.ScalePatternDoubleHarmonicMajor),
-		}),
+		"scalePatterns":       js.ValueOf(scalePatterns()),
 	}))
 }
 
+// scalePatterns lists the known scale patterns.
+func scalePatterns() []any {
+	var patterns []any
+	for p := range gohar.ScalePatterns() {
+		patterns = append(patterns, int(p))
+	}
+	return patterns
+}
+
 // SetLocale sets the locale/language for music notation and terminology.
 // Supported values: "en", "fr".
 //
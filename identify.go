@@ -0,0 +1,106 @@
+package gohar
+
+import (
+	"cmp"
+	"slices"
+)
+
+// A ChordCandidate is a possible interpretation of a set of pitches as a chord.
+type ChordCandidate struct {
+	// Chord is the identified chord. Its pattern is unpacked.
+	Chord
+	// Name is the chord's symbol, e.g. "C/E".
+	Name string
+	// Inversion is the index of the bass among the chord tones, 0 being the
+	// root position. See [Chord.Inversion].
+	Inversion int
+	// Complexity measures how unusual the chord is. Simpler chords (e.g. triads
+	// in root position) have a lower complexity.
+	Complexity int
+}
+
+// IdentifyChords tries every pitch of the set as a potential root, and returns
+// the corresponding chords, ranked from the simplest to the most complex.
+//
+// The lowest pitch is the bass: when it differs from the root, the chord is
+// reported as a slash chord. For instance, E-G-C is identified as "C/E" first.
+func IdentifyChords(pitches []Pitch) []ChordCandidate {
+	if len(pitches) == 0 {
+		return nil
+	}
+	bass := slices.Min(pitches).Normalize()
+	roots := make([]Pitch, 0, len(pitches))
+	for _, p := range pitches {
+		if p = p.Normalize(); !slices.Contains(roots, p) {
+			roots = append(roots, p)
+		}
+	}
+	slices.Sort(roots)
+
+	candidates := make([]ChordCandidate, 0, len(roots))
+	for _, root := range roots {
+		var pattern ChordPattern
+		for _, p := range pitches {
+			pattern = pattern.Add((p - root).Normalize())
+		}
+		candidates = append(candidates, newChordCandidate(
+			DefaultPitchClass(root),
+			pattern.Unpack(),
+			(bass-root).Normalize(),
+		))
+	}
+	slices.SortStableFunc(candidates, func(a, b ChordCandidate) int {
+		return cmp.Or(
+			cmp.Compare(a.Complexity, b.Complexity),
+			cmp.Compare(len(a.Name), len(b.Name)),
+		)
+	})
+	return candidates
+}
+
+func newChordCandidate(root PitchClass, pattern ChordPattern, bass Pitch) ChordCandidate {
+	candidate := ChordCandidate{
+		Chord:      Chord{Root: root, Pattern: pattern},
+		Complexity: chordComplexity(pattern),
+	}
+	for n, i := range pattern.AsIntervals() {
+		if n > 0 && i.PitchDiff.Normalize() == bass {
+			candidate.Bass = root.Transpose(i)
+			candidate.Inversion = n
+			candidate.Complexity++
+			break
+		}
+	}
+	candidate.Name = candidate.Chord.String()
+	return candidate
+}
+
+// chordComplexity scores an unpacked chord pattern: the more unusual degrees
+// it contains, the higher the score.
+func chordComplexity(c ChordPattern) int {
+	var score int
+	if !c.HasAnyDegree(PitchDiffMinorThird, PitchDiffMajorThird) {
+		score += 2
+	}
+	if !c.HasAnyDegree(PitchDiffDiminishedFifth, PitchDiffPerfectFifth, PitchDiffAugmentedFifth) {
+		score++
+	}
+	if c.HasAnyDegree(PitchDiffDiminishedFifth, PitchDiffAugmentedFifth) {
+		score++
+	}
+	if c.HasDegree(PitchDiffMajorSixth) && !c.Contains(ChordPatternDiminished7) {
+		score++
+	}
+	for p := PitchDiffOctave; p < 2*PitchDiffOctave; p++ {
+		if !c.HasDegree(p) {
+			continue
+		}
+		switch p {
+		case PitchDiffMinorNinth, PitchDiffAugmentedNinth, PitchDiffAugmentedEleventh, PitchDiffMinorThirteenth:
+			score += 2
+		default:
+			score++
+		}
+	}
+	return score
+}
@@ -0,0 +1,66 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestIdentifyChords(t *testing.T) {
+	Expect(t, IsEmptySlice(IdentifyChords(nil)))
+
+	testCases := []struct {
+		Pitches   []Pitch
+		WantName  string
+		WantInv   int
+		WantCount int
+	}{
+		{[]Pitch{PitchC, PitchE, PitchG}, "C", 0, 3},
+		{[]Pitch{PitchE, PitchG, PitchC + 12}, "C/E", 1, 3},
+		{[]Pitch{PitchG - 12, PitchC, PitchE}, "C/G", 2, 3},
+		{[]Pitch{PitchD, PitchF, PitchA, PitchC + 12}, "Dm7", 0, 4},
+		{[]Pitch{PitchC, PitchE, PitchG, PitchA}, "C6", 0, 4},
+		{[]Pitch{PitchA - 12, PitchC, PitchE, PitchG}, "Am7", 0, 4},
+		{[]Pitch{PitchG, PitchB, PitchD + 12, PitchF + 12, PitchA + 12}, "G9", 0, 5},
+		{[]Pitch{PitchB, PitchD + 12, PitchF + 12, PitchA + 12}, "Bm7♭5", 0, 4},
+		{[]Pitch{PitchC, PitchE, PitchG, PitchD + 12, PitchC + 24}, "Cadd9", 0, 4},
+	}
+	for _, tc := range testCases {
+		candidates := IdentifyChords(tc.Pitches)
+		Require(t, IsNotEmptySlice(candidates))
+		best := candidates[0]
+		Expect(t,
+			Equalf(tc.WantName, best.Name, "%v", tc.Pitches),
+			Equalf(tc.WantInv, best.Inversion, "%v inversion", tc.Pitches),
+			SliceHasLength(tc.WantCount, candidates),
+		)
+	}
+
+	// a single pitch class is a root without any chord tones
+	candidates := IdentifyChords([]Pitch{PitchC, PitchC + 12})
+	Require(t, SliceHasLength(1, candidates))
+	Expect(t, Equal(PitchClassC, candidates[0].Root))
+}
+
+func TestIdentifyChordsSpelling(t *testing.T) {
+	// diminished 7th chords are symmetrical: every note is a potential root.
+	candidates := IdentifyChords([]Pitch{PitchC, PitchEFlat, PitchGFlat, PitchA})
+	Require(t, SliceHasLength(4, candidates))
+	Expect(t, Equal("Cdim7", candidates[0].Name))
+	for _, c := range candidates[1:] {
+		Expect(t, IsTrue(c.Inversion > 0))
+		if c.Root == PitchClassE.Flat() {
+			// C is the diminished 7th of E♭
+			Expect(t, Equal(PitchClassD.DoubleFlat(), c.Bass))
+		}
+	}
+}
+
+func BenchmarkIdentifyChords(b *testing.B) {
+	pitches := []Pitch{PitchE, PitchBFlat, PitchD + 12, PitchG + 12, PitchC + 24}
+	for b.Loop() {
+		if len(IdentifyChords(pitches)) == 0 {
+			b.Fatal()
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package gohar
 
+import (
+	"fmt"
+	"math"
+)
+
 type Interval struct {
 	ScaleDiff int8
 	PitchDiff Pitch
@@ -40,3 +45,217 @@ var (
 func (i Interval) Down() Interval {
 	return Interval{-i.ScaleDiff, -i.PitchDiff}
 }
+
+// IntervalBetween returns the interval from note a to note b, taking their
+// spelling into account: C to D♯ is an augmented second, while C to E♭ is a
+// minor third. The interval is descending if b is lower than a.
+//
+// It is the inverse of [Note.Transpose]: a.Transpose(IntervalBetween(a, b)) == b.
+func IntervalBetween(a, b Note) Interval {
+	return Interval{
+		ScaleDiff: staffPosition(b) - staffPosition(a),
+		PitchDiff: b.Pitch() - a.Pitch(),
+	}
+}
+
+// staffPosition returns the number of scale steps between C0 and the base of the note.
+func staffPosition(n Note) int8 {
+	return n.Base() + 7*n.BaseOctave()
+}
+
+// Add returns the sum of both intervals, e.g. a major third plus a minor third
+// is a perfect fifth.
+func (i Interval) Add(j Interval) Interval {
+	return Interval{i.ScaleDiff + j.ScaleDiff, i.PitchDiff + j.PitchDiff}
+}
+
+// Sub returns the difference between both intervals, e.g. a perfect fifth minus
+// a major third is a minor third.
+func (i Interval) Sub(j Interval) Interval {
+	return i.Add(j.Down())
+}
+
+// Simple reduces a compound interval to an interval within an octave, keeping
+// its quality and direction: a major ninth becomes a major second. Octaves,
+// augmented octaves and simple intervals are left untouched.
+func (i Interval) Simple() Interval {
+	if i.IsDescending() {
+		return i.Down().Simple().Down()
+	}
+	for i.ScaleDiff > 7 {
+		i = i.Sub(IntOctave)
+	}
+	return i
+}
+
+// Compound returns the interval extended by given number of octaves, in its
+// own direction: a major second compounded by one octave is a major ninth.
+func (i Interval) Compound(octaves int8) Interval {
+	o := Interval{7 * octaves, PitchDiffOctave * Pitch(octaves)}
+	if i.IsDescending() {
+		return i.Sub(o)
+	}
+	return i.Add(o)
+}
+
+// Invert returns the inversion of the interval, i.e. the interval that completes
+// it to an octave: a major third becomes a minor sixth, an augmented fourth
+// becomes a diminished fifth. Compound intervals are reduced to simple intervals
+// first, and descending intervals remain descending.
+func (i Interval) Invert() Interval {
+	if i.IsDescending() {
+		return i.Down().Invert().Down()
+	}
+	return IntOctave.Sub(i.Simple())
+}
+
+// An IntervalQuality is the quality of an interval: perfect, major, minor,
+// augmented, diminished...
+type IntervalQuality int8
+
+const (
+	QualityInvalid IntervalQuality = iota
+	QualityDoublyDiminished
+	QualityDiminished
+	QualityMinor
+	QualityPerfect
+	QualityMajor
+	QualityAugmented
+	QualityDoublyAugmented
+)
+
+var qualityAbbreviations = [...]string{
+	QualityInvalid:          "",
+	QualityDoublyDiminished: "dd",
+	QualityDiminished:       "d",
+	QualityMinor:            "m",
+	QualityPerfect:          "P",
+	QualityMajor:            "M",
+	QualityAugmented:        "A",
+	QualityDoublyAugmented:  "AA",
+}
+
+// String returns the usual abbreviation of the quality (e.g. "M", "P", "dd").
+// "<invalid>" is returned if the quality is invalid.
+func (q IntervalQuality) String() string {
+	if q <= QualityInvalid || int(q) >= len(qualityAbbreviations) {
+		return "<invalid>"
+	}
+	return qualityAbbreviations[q]
+}
+
+// NewInterval builds an ascending interval from its quality and its (1-based) number.
+// For instance, NewInterval(QualityMajor, 3) returns IntMajorThird.
+//
+// ErrInvalidInterval is returned if number < 1, if the quality cannot apply
+// to the number (e.g. a perfect third or a major fifth), or if the interval is
+// too wide to be represented.
+func NewInterval(q IntervalQuality, number int) (Interval, error) {
+	if number < 1 {
+		return Interval{}, wrapErrorf(ErrInvalidInterval, "number %d", number)
+	}
+	if q == QualityInvalid {
+		return Interval{}, wrapErrorf(ErrInvalidInterval, "invalid quality")
+	}
+	scaleDiff := number - 1
+	natural := int(asPitch[scaleDiff%7]) + scaleDiff/7*int(PitchDiffOctave)
+	offsets := majorQualityOffsets
+	if isPerfectDegree(scaleDiff) {
+		offsets = perfectQualityOffsets
+	}
+	for offset, quality := range offsets {
+		if quality != q {
+			continue
+		}
+		pitchDiff := natural + offset - 3
+		if pitchDiff > math.MaxInt8 {
+			return Interval{}, wrapErrorf(ErrInvalidInterval, "%s%d is too wide", q, number)
+		}
+		return Interval{int8(scaleDiff), Pitch(pitchDiff)}, nil
+	}
+	return Interval{}, wrapErrorf(ErrInvalidInterval, "quality %s with number %d", q, number)
+}
+
+// Quality and pitch offset relative to the major or perfect interval, shifted by 3.
+var (
+	perfectQualityOffsets = [...]IntervalQuality{
+		QualityInvalid,
+		QualityDoublyDiminished,
+		QualityDiminished,
+		QualityPerfect,
+		QualityAugmented,
+		QualityDoublyAugmented,
+	}
+	majorQualityOffsets = [...]IntervalQuality{
+		QualityDoublyDiminished,
+		QualityDiminished,
+		QualityMinor,
+		QualityMajor,
+		QualityAugmented,
+		QualityDoublyAugmented,
+	}
+)
+
+func isPerfectDegree(scaleDiff int) bool {
+	switch scaleDiff % 7 {
+	case 0, 3, 4:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDescending returns true if the interval goes down.
+func (i Interval) IsDescending() bool {
+	return i.ScaleDiff < 0 || (i.ScaleDiff == 0 && i.PitchDiff < 0)
+}
+
+// abs returns the ascending version of the interval.
+func (i Interval) abs() Interval {
+	if i.IsDescending() {
+		return i.Down()
+	}
+	return i
+}
+
+// Number returns the number of the interval, i.e. the number of staff positions it
+// encompasses: 1 for a unison, 3 for a third, 9 for a ninth...
+// The number of a descending interval is the same as its ascending counterpart.
+func (i Interval) Number() int {
+	return int(i.abs().ScaleDiff) + 1
+}
+
+// Quality returns the quality of the interval (major, minor, perfect...).
+// The quality of a descending interval is the same as its ascending counterpart.
+//
+// QualityInvalid is returned if the interval is more than doubly augmented or
+// doubly diminished.
+func (i Interval) Quality() IntervalQuality {
+	i = i.abs()
+	scaleDiff := int(i.ScaleDiff)
+	natural := asPitch[scaleDiff%7] + Pitch(scaleDiff/7)*PitchDiffOctave
+	offset := int(i.PitchDiff-natural) + 3
+	offsets := majorQualityOffsets
+	if isPerfectDegree(scaleDiff) {
+		offsets = perfectQualityOffsets
+	}
+	if offset < 0 || offset >= len(offsets) {
+		return QualityInvalid
+	}
+	return offsets[offset]
+}
+
+// String returns the short name of the interval, such as "M3", "P5", "A4" or "m9".
+// Descending intervals are prefixed with a minus sign (e.g. "-P5").
+// "<invalid>" is returned if the interval has no valid quality.
+func (i Interval) String() string {
+	q := i.Quality()
+	if q == QualityInvalid {
+		return "<invalid>"
+	}
+	s := fmt.Sprintf("%s%d", q, i.Number())
+	if i.IsDescending() {
+		return "-" + s
+	}
+	return s
+}
@@ -1 +1,129 @@
 package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestIntervalQualityAndNumber(t *testing.T) {
+	testCases := []struct {
+		Interval
+		Quality IntervalQuality
+		Number  int
+		String  string
+	}{
+		{IntUnisson, QualityPerfect, 1, "P1"},
+		{IntMinorSecond, QualityMinor, 2, "m2"},
+		{IntAugmentedSecond, QualityAugmented, 2, "A2"},
+		{IntMajorThird, QualityMajor, 3, "M3"},
+		{IntDiminishedFourth, QualityDiminished, 4, "d4"},
+		{IntAugmentedFourth, QualityAugmented, 4, "A4"},
+		{IntPerfectFifth, QualityPerfect, 5, "P5"},
+		{IntDiminishedSeventh, QualityDiminished, 7, "d7"},
+		{IntOctave, QualityPerfect, 8, "P8"},
+		{IntMinorNinth, QualityMinor, 9, "m9"},
+		{IntAugmentedEleventh, QualityAugmented, 11, "A11"},
+		{IntMajorThirteenth, QualityMajor, 13, "M13"},
+		{Interval{3, 3}, QualityDoublyDiminished, 4, "dd4"},
+		{Interval{2, 6}, QualityDoublyAugmented, 3, "AA3"},
+		{IntPerfectFifth.Down(), QualityPerfect, 5, "-P5"},
+		{IntMinorThird.Down(), QualityMinor, 3, "-m3"},
+		{Interval{0, -1}, QualityAugmented, 1, "-A1"},
+		{Interval{2, 0}, QualityInvalid, 3, "<invalid>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.String, func(t *testing.T) {
+			Expect(t,
+				Equal(tc.Quality, tc.Interval.Quality()),
+				Equal(tc.Number, tc.Interval.Number()),
+				Equal(tc.String, tc.Interval.String()),
+			)
+		})
+	}
+}
+
+func TestNewInterval(t *testing.T) {
+	isError := HasError[Interval]
+	isInterval := AsCheckFunc(func(want, got Interval) error {
+		return Equal(want, got)
+	})
+	testCases := []struct {
+		Quality IntervalQuality
+		Number  int
+		Check   CheckFunc[Interval]
+	}{
+		{QualityPerfect, 0, isError(ErrInvalidInterval)},
+		{QualityInvalid, 3, isError(ErrInvalidInterval)},
+		{QualityPerfect, 3, isError(ErrInvalidInterval)},
+		{QualityMajor, 5, isError(ErrInvalidInterval)},
+		{QualityMinor, 1, isError(ErrInvalidInterval)},
+		{QualityPerfect, 1, isInterval(IntUnisson)},
+		{QualityMajor, 3, isInterval(IntMajorThird)},
+		{QualityAugmented, 4, isInterval(IntAugmentedFourth)},
+		{QualityDiminished, 7, isInterval(IntDiminishedSeventh)},
+		{QualityAugmented, 6, isInterval(IntAugmentedSixth)},
+		{QualityPerfect, 8, isInterval(IntOctave)},
+		{QualityMinor, 13, isInterval(IntMinorThirteenth)},
+		{QualityDoublyDiminished, 4, isInterval(Interval{3, 3})},
+		{QualityPerfect, 75, isInterval(Interval{74, 127})},
+		{QualityAugmented, 75, isError(ErrInvalidInterval)},
+		{QualityMajor, 99, isError(ErrInvalidInterval)},
+	}
+
+	for _, tc := range testCases {
+		got, err := NewInterval(tc.Quality, tc.Number)
+		Expect(t, tc.Check(got, err))
+	}
+}
+
+func TestIntervalBetween(t *testing.T) {
+	testCases := []struct {
+		A, B Note
+		Want Interval
+	}{
+		{NoteC, NoteC, IntUnisson},
+		{NoteC, NoteD.Sharp(), IntAugmentedSecond},
+		{NoteC, NoteE.Flat(), IntMinorThird},
+		{NoteC, NoteC.Octave(1), IntOctave},
+		{NoteD, NoteB.Flat().Octave(1), IntMinorThirteenth},
+		{NoteC, NoteF.Octave(-1), IntPerfectFifth.Down()},
+		{NoteB, NoteC.Octave(1).Flat(), Interval{1, 0}},
+		{NoteB.Sharp(), NoteC.Octave(1), Interval{1, 0}},
+		{NoteC.Flat(), NoteB.Sharp(), Interval{6, 13}},
+		{NoteE.Flat(), NoteC.Octave(1), IntMajorSixth},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.A.String()+"-"+tc.B.String(), func(t *testing.T) {
+			got := IntervalBetween(tc.A, tc.B)
+			Expect(t,
+				Equal(tc.Want, got),
+				noteEqual(tc.B, tc.A.Transpose(got)),
+			)
+		})
+	}
+}
+
+func TestIntervalArithmetic(t *testing.T) {
+	Expect(t,
+		Equal(IntPerfectFifth, IntMajorThird.Add(IntMinorThird)),
+		Equal(IntMinorSeventh, IntPerfectFifth.Add(IntMinorThird)),
+		Equal(IntMinorThird, IntPerfectFifth.Sub(IntMajorThird)),
+		Equal(IntMajorThird.Down(), IntUnisson.Sub(IntMajorThird)),
+		Equal(IntMajorSecond, IntMajorNinth.Simple()),
+		Equal(IntOctave, IntOctave.Simple()),
+		Equal(IntPerfectFourth, Interval{17, 29}.Simple()),
+		Equal(IntMinorSecond.Down(), IntMinorNinth.Down().Simple()),
+		Equal(IntMajorNinth, IntMajorSecond.Compound(1)),
+		Equal(Interval{18, 31}, IntPerfectFifth.Compound(2)),
+		Equal(IntMinorTenth.Down(), IntMinorThird.Down().Compound(1)),
+		Equal(IntMinorSixth, IntMajorThird.Invert()),
+		Equal(IntDiminishedFifth, IntAugmentedFourth.Invert()),
+		Equal(IntOctave, IntUnisson.Invert()),
+		Equal(IntUnisson, IntOctave.Invert()),
+		Equal(IntMajorSeventh, IntMinorNinth.Invert()),
+		Equal(IntMajorSixth.Down(), IntMinorThird.Down().Invert()),
+	)
+}
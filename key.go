@@ -0,0 +1,261 @@
+package gohar
+
+import (
+	"slices"
+	"strings"
+)
+
+// A Key is a tonic and a scale pattern that defines a key signature: the major
+// scale, its modes (such as dorian or aeolian), or the harmonic and melodic
+// minor scales, that share the signature of the natural minor scale.
+type Key struct {
+	Scale
+}
+
+// NewKey returns the key with given tonic and scale pattern.
+//
+// ErrInvalidPitchClass is returned if the tonic is invalid, and ErrInvalidKey
+// if the pattern has no key signature.
+func NewKey(tonic PitchClass, pattern ScalePattern) (Key, error) {
+	if !tonic.IsValid() {
+		return Key{}, wrapErrorf(ErrInvalidPitchClass, "%s", tonic)
+	}
+	if _, ok := majorModeDegree(pattern); !ok {
+		return Key{}, wrapErrorf(ErrInvalidKey, "%012b has no key signature", pattern)
+	}
+	return Key{Scale{tonic, pattern}}, nil
+}
+
+// NewKeyFromSignature returns the key with given scale pattern whose signature
+// has given number of sharps (if positive) or flats (if negative).
+//
+// ErrInvalidKey is returned if the pattern has no key signature, or if there are
+// more than 7 sharps or flats.
+func NewKeyFromSignature(fifths int, pattern ScalePattern) (Key, error) {
+	degree, ok := majorModeDegree(pattern)
+	if !ok {
+		return Key{}, wrapErrorf(ErrInvalidKey, "%012b has no key signature", pattern)
+	}
+	if fifths < -7 || fifths > 7 {
+		return Key{}, wrapErrorf(ErrInvalidKey, "%d fifths", fifths)
+	}
+	parent := NewMajorKey(PitchClassC).Step(fifths)
+	tonic := slices.Collect(parent.PitchClasses())[degree-1]
+	return Key{Scale{tonic, pattern}}, nil
+}
+
+// NewMajorKey returns the major key with given tonic.
+func NewMajorKey(tonic PitchClass) Key {
+	return Key{Scale{tonic, ScalePatternMajor}}
+}
+
+// NewMinorKey returns the (natural) minor key with given tonic.
+func NewMinorKey(tonic PitchClass) Key {
+	return Key{Scale{tonic, ScalePatternNaturalMinor}}
+}
+
+// majorModeDegree returns the degree of the major scale that the pattern is a
+// mode of. Harmonic and melodic minor scales are considered as the aeolian mode.
+func majorModeDegree(pattern ScalePattern) (int, bool) {
+	switch pattern {
+	case ScalePatternHarmonicMinor, ScalePatternMelodicMinor:
+		return 6, true
+	}
+	for degree := 1; degree <= 7; degree++ {
+		if mode, _ := ScalePatternMajor.Mode(degree); mode == pattern {
+			return degree, true
+		}
+	}
+	return 0, false
+}
+
+// modeNames are the names of the modes of the major scale, by degree.
+var modeNames = [7]string{"major", "dorian", "phrygian", "lydian", "mixolydian", "minor", "locrian"}
+
+// modeAliases are the other names of the modes of the major scale, by degree.
+var modeAliases = [7]string{0: "ionian", 5: "aeolian"}
+
+// Mode returns the name of the mode of the key: "major", "dorian", "phrygian",
+// "lydian", "mixolydian", "minor" or "locrian". Harmonic and melodic minor keys
+// are minor keys.
+func (k Key) Mode() string {
+	degree, ok := majorModeDegree(k.Pattern)
+	if !ok {
+		return ""
+	}
+	return modeNames[degree-1]
+}
+
+// ParseKeyMode returns the scale pattern of a mode of key signature, given its
+// name (see [Key.Mode]) in any case. "ionian" and "aeolian" are accepted as well,
+// names may be shortened down to their first three letters ("Dor", "min"), and
+// an empty name is major.
+func ParseKeyMode(mode string) (ScalePattern, error) {
+	name := strings.ToLower(mode)
+	if name == "" {
+		return ScalePatternMajor, nil
+	}
+	if len(name) >= 3 {
+		for degree, n := range modeNames {
+			if strings.HasPrefix(n, name) || strings.HasPrefix(modeAliases[degree], name) {
+				return ScalePatternMajor.Mode(degree + 1)
+			}
+		}
+	}
+	return 0, wrapErrorf(ErrInvalidKey, "unknown mode %q", mode)
+}
+
+// IsMinor returns true if the key has a minor third.
+func (k Key) IsMinor() bool {
+	return k.Pattern&(1<<PitchDiffMinorThird) != 0
+}
+
+// Fifths returns the number of sharps (if positive) or flats (if negative)
+// of the key signature.
+func (k Key) Fifths() int {
+	return fifthsOf(k.parentMajor())
+}
+
+// fifths of the natural notes in major keys, from C to B
+var naturalFifths = [7]int{0, 2, 4, -1, 1, 3, 5}
+
+func fifthsOf(tonic PitchClass) int {
+	return naturalFifths[tonic.Base()] + 7*int(tonic.Alt())
+}
+
+// parentMajor returns the tonic of the major key that has the same signature.
+func (k Key) parentMajor() PitchClass {
+	degree, ok := majorModeDegree(k.Pattern)
+	if !ok {
+		return k.Root
+	}
+	intervals := slices.Collect(ScalePatternMajor.Intervals())
+	return k.Root.Transpose(intervals[degree-1].Down())
+}
+
+var (
+	sharpsOrder = [7]PitchClass{
+		PitchClassF, PitchClassC, PitchClassG, PitchClassD,
+		PitchClassA, PitchClassE, PitchClassB,
+	}
+	flatsOrder = [7]PitchClass{
+		PitchClassB, PitchClassE, PitchClassA, PitchClassD,
+		PitchClassG, PitchClassC, PitchClassF,
+	}
+)
+
+// Signature returns the accidentals of the key signature, in the order in which
+// they are written (F♯ C♯ G♯... or B♭ E♭ A♭...).
+//
+// Theoretical keys (see [Key.IsTheoretical]) have double sharps or double flats:
+// the signature of G♯ major is F𝄪 C♯ G♯ D♯ A♯ E♯ B♯.
+func (k Key) Signature() []PitchClass {
+	fifths := k.Fifths()
+	order, alt := sharpsOrder, Pitch(1)
+	if fifths < 0 {
+		fifths, order, alt = -fifths, flatsOrder, -1
+	}
+	signature := make([]PitchClass, min(fifths, 7))
+	for i := range signature {
+		if i < fifths-7 {
+			signature[i] = order[i].WithAlt(2 * alt)
+		} else {
+			signature[i] = order[i].WithAlt(alt)
+		}
+	}
+	return signature
+}
+
+// IsTheoretical returns true if the key signature has more than 7 accidentals,
+// such as G♯ major or F♭ major.
+func (k Key) IsTheoretical() bool {
+	fifths := k.Fifths()
+	return fifths > 7 || fifths < -7
+}
+
+// Enharmonic returns the enharmonically equivalent key, whose tonic is spelled
+// with the neighbouring note name: keys with sharps give keys with flats (G♯
+// major gives A♭ major) and conversely.
+//
+// The key is returned unchanged if its enharmonic key would be theoretical (see
+// [Key.IsTheoretical]) or have more accidentals: C major doesn't give B♯ major,
+// nor does D♭ major give C♯ major.
+func (k Key) Enharmonic() Key {
+	step := Interval{1, 0}
+	if k.Fifths() <= 0 {
+		step = step.Down()
+	}
+	enharmonic := k
+	enharmonic.Root = k.Root.Transpose(step)
+	fifths, enharmonicFifths := k.Fifths(), enharmonic.Fifths()
+	if enharmonic.IsTheoretical() || max(enharmonicFifths, -enharmonicFifths) > max(fifths, -fifths) {
+		return k
+	}
+	return enharmonic
+}
+
+// Relative returns the relative key, that has the same signature: the relative
+// minor of major keys, the relative major of minor keys, or the major key of
+// which a modal key is a mode.
+func (k Key) Relative() Key {
+	if k.Pattern == ScalePatternMajor {
+		return NewMinorKey(k.Root.Transpose(IntMajorSixth))
+	}
+	return NewMajorKey(k.parentMajor())
+}
+
+// Parallel returns the parallel key, that has the same tonic: the natural minor
+// key for keys with a major third, and the major key for keys with a minor third.
+func (k Key) Parallel() Key {
+	if k.IsMinor() {
+		return NewMajorKey(k.Root)
+	}
+	return NewMinorKey(k.Root)
+}
+
+// Step moves the key by given number of steps around the circle of fifths,
+// clockwise (towards the sharps) if steps > 0, or counter-clockwise (towards the
+// flats) if steps < 0.
+//
+// The resulting key is never theoretical (see [Key.IsTheoretical]): going past
+// 7 sharps or flats continues from the enharmonic key, so that twelve steps lead
+// back to the same key.
+func (k Key) Step(steps int) Key {
+	fifths := k.Fifths()
+	target := fifths + steps%12
+	switch {
+	case target > 7:
+		target -= 12
+	case target < -7:
+		target += 12
+	}
+	steps = target - fifths
+	fifth := IntPerfectFifth
+	if steps < 0 {
+		steps, fifth = -steps, IntPerfectFourth
+	}
+	for range steps {
+		k.Root = k.Root.Transpose(fifth)
+	}
+	return k
+}
+
+// Dominant returns the key of the dominant, i.e. the next key clockwise on the
+// circle of fifths.
+func (k Key) Dominant() Key {
+	return k.Step(1)
+}
+
+// Subdominant returns the key of the subdominant, i.e. the next key
+// counter-clockwise on the circle of fifths.
+func (k Key) Subdominant() Key {
+	return k.Step(-1)
+}
+
+// Distance returns the number of accidentals to add to the key signature in
+// order to reach the signature of given key, i.e. the number of steps between
+// both keys around the circle of fifths. The result is positive if the other
+// key has more sharps (or fewer flats), negative otherwise.
+func (k Key) Distance(other Key) int {
+	return other.Fifths() - k.Fifths()
+}
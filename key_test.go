@@ -0,0 +1,221 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestNewKey(t *testing.T) {
+	isError := HasError[Key]
+	isKey := AsCheckFunc(func(want, got Key) error {
+		return Equal(want, got)
+	})
+	testCases := []struct {
+		Tonic   PitchClass
+		Pattern ScalePattern
+		Check   CheckFunc[Key]
+	}{
+		{0, ScalePatternMajor, isError(ErrInvalidPitchClass)},
+		{PitchClassC, ScalePatternWholeTone, isError(ErrInvalidKey)},
+		{PitchClassC, ScalePatternAltered, isError(ErrInvalidKey)},
+		{PitchClassC, ScalePatternMajor, isKey(NewMajorKey(PitchClassC))},
+		{PitchClassA, ScalePatternNaturalMinor, isKey(NewMinorKey(PitchClassA))},
+		{PitchClassA, ScalePatternHarmonicMinor, isKey(Key{Scale{PitchClassA, ScalePatternHarmonicMinor}})},
+		{PitchClassD, ScalePatternDorian, isKey(Key{Scale{PitchClassD, ScalePatternDorian}})},
+	}
+
+	for _, tc := range testCases {
+		got, err := NewKey(tc.Tonic, tc.Pattern)
+		Expect(t, tc.Check(got, err))
+	}
+}
+
+func TestNewKeyFromSignature(t *testing.T) {
+	isError := HasError[Key]
+	isKey := AsCheckFunc(func(want, got Key) error {
+		return Equal(want, got)
+	})
+	testCases := []struct {
+		Fifths  int
+		Pattern ScalePattern
+		Check   CheckFunc[Key]
+	}{
+		{0, ScalePatternMajor, isKey(NewMajorKey(PitchClassC))},
+		{0, ScalePatternNaturalMinor, isKey(NewMinorKey(PitchClassA))},
+		{-3, ScalePatternHarmonicMinor, isKey(Key{Scale{PitchClassC, ScalePatternHarmonicMinor}})},
+		{2, ScalePatternDorian, isKey(Key{Scale{PitchClassE, ScalePatternDorian}})},
+		{7, ScalePatternMajor, isKey(NewMajorKey(PitchClassC.Sharp()))},
+		{-7, ScalePatternLocrian, isKey(Key{Scale{PitchClassB.Flat(), ScalePatternLocrian}})},
+		{8, ScalePatternMajor, isError(ErrInvalidKey)},
+		{-8, ScalePatternMajor, isError(ErrInvalidKey)},
+		{0, ScalePatternWholeTone, isError(ErrInvalidKey)},
+	}
+
+	for _, tc := range testCases {
+		got, err := NewKeyFromSignature(tc.Fifths, tc.Pattern)
+		Expect(t, tc.Check(got, err))
+	}
+}
+
+func TestKeyMode(t *testing.T) {
+	testCases := []struct {
+		Key
+		Want string
+	}{
+		{NewMajorKey(PitchClassC), "major"},
+		{NewMinorKey(PitchClassA), "minor"},
+		{Key{Scale{PitchClassA, ScalePatternHarmonicMinor}}, "minor"},
+		{Key{Scale{PitchClassA, ScalePatternMelodicMinor}}, "minor"},
+		{Key{Scale{PitchClassD, ScalePatternDorian}}, "dorian"},
+		{Key{Scale{PitchClassE, ScalePatternPhrygian}}, "phrygian"},
+		{Key{Scale{PitchClassF, ScalePatternLydian}}, "lydian"},
+		{Key{Scale{PitchClassG, ScalePatternMixolydian}}, "mixolydian"},
+		{Key{Scale{PitchClassB, ScalePatternLocrian}}, "locrian"},
+		{Key{Scale{PitchClassC, ScalePatternWholeTone}}, ""},
+	}
+
+	for _, tc := range testCases {
+		Expect(t, Equalf(tc.Want, tc.Mode(), "%s", tc.Key))
+	}
+}
+
+func TestParseKeyMode(t *testing.T) {
+	isError := HasError[ScalePattern]
+	isPattern := AsCheckFunc(func(want, got ScalePattern) error {
+		return Equal(want, got)
+	})
+	testCases := []struct {
+		Input string
+		Check CheckFunc[ScalePattern]
+	}{
+		{"", isPattern(ScalePatternMajor)},
+		{"major", isPattern(ScalePatternMajor)},
+		{"Maj", isPattern(ScalePatternMajor)},
+		{"ionian", isPattern(ScalePatternMajor)},
+		{"minor", isPattern(ScalePatternNaturalMinor)},
+		{"min", isPattern(ScalePatternNaturalMinor)},
+		{"AEOLIAN", isPattern(ScalePatternNaturalMinor)},
+		{"Dor", isPattern(ScalePatternDorian)},
+		{"phrygian", isPattern(ScalePatternPhrygian)},
+		{"lyd", isPattern(ScalePatternLydian)},
+		{"Mixolydian", isPattern(ScalePatternMixolydian)},
+		{"loc", isPattern(ScalePatternLocrian)},
+		{"m", isError(ErrInvalidKey)},
+		{"mi", isError(ErrInvalidKey)},
+		{"mixed", isError(ErrInvalidKey)},
+		{"blues", isError(ErrInvalidKey)},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseKeyMode(tc.Input)
+		Expect(t, tc.Check(got, err))
+	}
+}
+
+func TestKeySignature(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Key
+		Want []PitchClass
+	}{
+		{"C major", NewMajorKey(PitchClassC), []PitchClass{}},
+		{"A minor", NewMinorKey(PitchClassA), []PitchClass{}},
+		{"D major", NewMajorKey(PitchClassD), []PitchClass{PitchClassF.Sharp(), PitchClassC.Sharp()}},
+		{
+			"C minor", NewMinorKey(PitchClassC),
+			[]PitchClass{PitchClassB.Flat(), PitchClassE.Flat(), PitchClassA.Flat()},
+		},
+		{
+			"C harmonic minor", Key{Scale{PitchClassC, ScalePatternHarmonicMinor}},
+			[]PitchClass{PitchClassB.Flat(), PitchClassE.Flat(), PitchClassA.Flat()},
+		},
+		{"E dorian", Key{Scale{PitchClassE, ScalePatternDorian}}, []PitchClass{PitchClassF.Sharp(), PitchClassC.Sharp()}},
+		{"B♭ lydian", Key{Scale{PitchClassB.Flat(), ScalePatternLydian}}, []PitchClass{PitchClassB.Flat()}},
+		{
+			"G♯ major", NewMajorKey(PitchClassG.Sharp()),
+			[]PitchClass{
+				PitchClassF.DoubleSharp(), PitchClassC.Sharp(), PitchClassG.Sharp(), PitchClassD.Sharp(),
+				PitchClassA.Sharp(), PitchClassE.Sharp(), PitchClassB.Sharp(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		Expect(t, Equalf(tc.Want, tc.Key.Signature(), "%s", tc.Name))
+	}
+}
+
+func TestKeyFifths(t *testing.T) {
+	Expect(t,
+		Equal(0, NewMajorKey(PitchClassC).Fifths()),
+		Equal(7, NewMajorKey(PitchClassC.Sharp()).Fifths()),
+		Equal(-7, NewMajorKey(PitchClassC.Flat()).Fifths()),
+		Equal(-1, NewMajorKey(PitchClassF).Fifths()),
+		Equal(3, NewMinorKey(PitchClassF.Sharp()).Fifths()),
+		Equal(-6, NewMinorKey(PitchClassE.Flat()).Fifths()),
+		Equal(8, NewMajorKey(PitchClassG.Sharp()).Fifths()),
+		Equal(false, NewMajorKey(PitchClassC.Sharp()).IsTheoretical()),
+		Equal(true, NewMajorKey(PitchClassG.Sharp()).IsTheoretical()),
+		Equal(true, NewMajorKey(PitchClassF.Flat()).IsTheoretical()),
+		Equal(true, NewMinorKey(PitchClassD.Flat()).IsTheoretical()),
+	)
+}
+
+func TestKeyRelations(t *testing.T) {
+	cMajor := NewMajorKey(PitchClassC)
+	Expect(t,
+		Equal(NewMinorKey(PitchClassA), cMajor.Relative()),
+		Equal(cMajor, NewMinorKey(PitchClassA).Relative()),
+		Equal(NewMajorKey(PitchClassE.Flat()), Key{Scale{PitchClassC, ScalePatternHarmonicMinor}}.Relative()),
+		Equal(cMajor, Key{Scale{PitchClassD, ScalePatternDorian}}.Relative()),
+		Equal(NewMinorKey(PitchClassC), cMajor.Parallel()),
+		Equal(cMajor, NewMinorKey(PitchClassC).Parallel()),
+		Equal(NewMajorKey(PitchClassG), cMajor.Dominant()),
+		Equal(NewMajorKey(PitchClassF), cMajor.Subdominant()),
+		Equal(NewMinorKey(PitchClassE), NewMinorKey(PitchClassA).Dominant()),
+		Equal(NewMajorKey(PitchClassF.Sharp()), cMajor.Step(6)),
+		Equal(NewMajorKey(PitchClassG.Flat()), cMajor.Step(-6)),
+		Equal(NewMajorKey(PitchClassC), cMajor.Step(0)),
+		Equal(cMajor, cMajor.Step(12)),
+		Equal(cMajor, cMajor.Step(-24)),
+		Equal(NewMajorKey(PitchClassA.Flat()), NewMajorKey(PitchClassC.Sharp()).Dominant()),
+		Equal(NewMajorKey(PitchClassE), NewMajorKey(PitchClassC.Flat()).Subdominant()),
+		Equal(NewMajorKey(PitchClassA.Flat()), cMajor.Step(20)),
+		Equal(NewMajorKey(PitchClassE), cMajor.Step(-20)),
+		Equal(NewMinorKey(PitchClassD), NewMinorKey(PitchClassA).Step(-121)),
+		Equal(NewMajorKey(PitchClassA.Flat()), NewMajorKey(PitchClassG.Sharp()).Step(0)),
+	)
+
+	for steps := -300; steps <= 300; steps++ {
+		key := Key{Scale{PitchClassD, ScalePatternDorian}}.Step(steps)
+		Expect(t,
+			IsTruef(key.Root.IsValid(), "step %d: %s", steps, key.Root),
+			IsTruef(!key.IsTheoretical(), "step %d: %s", steps, key.Root),
+		)
+	}
+}
+
+func TestKeyEnharmonic(t *testing.T) {
+	Expect(t,
+		Equal(NewMajorKey(PitchClassA.Flat()), NewMajorKey(PitchClassG.Sharp()).Enharmonic()),
+		Equal(NewMajorKey(PitchClassE), NewMajorKey(PitchClassF.Flat()).Enharmonic()),
+		Equal(NewMajorKey(PitchClassD.Flat()), NewMajorKey(PitchClassC.Sharp()).Enharmonic()),
+		Equal(NewMinorKey(PitchClassC.Sharp()), NewMinorKey(PitchClassD.Flat()).Enharmonic()),
+		Equal(NewMajorKey(PitchClassG.Flat()), NewMajorKey(PitchClassF.Sharp()).Enharmonic()),
+		// unchanged rather than theoretical or with more accidentals
+		Equal(NewMajorKey(PitchClassC), NewMajorKey(PitchClassC).Enharmonic()),
+		Equal(NewMinorKey(PitchClassA), NewMinorKey(PitchClassA).Enharmonic()),
+		Equal(NewMajorKey(PitchClassD.Flat()), NewMajorKey(PitchClassD.Flat()).Enharmonic()),
+		Equal(NewMajorKey(PitchClassB), NewMajorKey(PitchClassB).Enharmonic()),
+	)
+}
+
+func TestKeyDistance(t *testing.T) {
+	Expect(t,
+		Equal(2, NewMajorKey(PitchClassC).Distance(NewMajorKey(PitchClassD))),
+		Equal(-3, NewMajorKey(PitchClassC).Distance(NewMinorKey(PitchClassC))),
+		Equal(0, NewMajorKey(PitchClassC).Distance(NewMinorKey(PitchClassA))),
+		Equal(-12, NewMajorKey(PitchClassB.Sharp()).Distance(NewMajorKey(PitchClassC))),
+	)
+}
@@ -1,8 +1,7 @@
 package keyboard
 
 import (
-	//lint:ignore ST1001 core types
-	. "github.com/ArnaudCalmettes/gohar"
+	"github.com/ArnaudCalmettes/gohar"
 )
 
 type Keyboard struct {
@@ -10,7 +9,7 @@ type Keyboard struct {
 }
 
 type Key struct {
-	Pitch
+	gohar.Pitch
 	Flags KeyFlag
 }
 
@@ -29,7 +28,7 @@ func (k Key) IsPressed() bool {
 	return k.Flags&keyFlagPressed != 0
 }
 
-func New(lowest, highest Pitch) *Keyboard {
+func New(lowest, highest gohar.Pitch) *Keyboard {
 	lowest, highest, ambitus := adjustAmbitus(lowest, highest)
 	keys := make([]Key, 0, int(ambitus)+1)
 	for pitch := lowest; pitch <= highest; pitch++ {
@@ -42,7 +41,7 @@ func New(lowest, highest Pitch) *Keyboard {
 	return &Keyboard{Keys: keys}
 }
 
-func (k *Keyboard) Press(pitches ...Pitch) {
+func (k *Keyboard) Press(pitches ...gohar.Pitch) {
 	lowest := k.Keys[0].Pitch
 	for _, pitch := range pitches {
 		i := int(pitch - lowest)
@@ -52,9 +51,17 @@ func (k *Keyboard) Press(pitches ...Pitch) {
 	}
 }
 
+// PressNotes presses the keys that correspond to the notes, such as the
+// notes of a chord voicing.
+func (k *Keyboard) PressNotes(notes ...gohar.Note) {
+	for _, n := range notes {
+		k.Press(n.Pitch())
+	}
+}
+
 // Adjust boundaries so the leftmost and rightmost keys are white
 // and the keyboard is at least one octave wide.
-func adjustAmbitus(low, high Pitch) (lowest, highest, ambitus Pitch) {
+func adjustAmbitus(low, high gohar.Pitch) (lowest, highest, ambitus gohar.Pitch) {
 	lowest, highest = low, high
 	if ambitus = high - low; ambitus < 0 {
 		lowest, highest, ambitus = highest, lowest, -ambitus
@@ -67,16 +74,16 @@ func adjustAmbitus(low, high Pitch) (lowest, highest, ambitus Pitch) {
 		highest++
 		ambitus++
 	}
-	if ambitus < PitchDiffOctave {
-		highest = lowest + PitchDiffOctave
-		ambitus = PitchDiffOctave
+	if ambitus < gohar.PitchDiffOctave {
+		highest = lowest + gohar.PitchDiffOctave
+		ambitus = gohar.PitchDiffOctave
 	}
 	return
 }
 
-func isBlackKey(pitch Pitch) bool {
+func isBlackKey(pitch gohar.Pitch) bool {
 	switch pitch.Normalize() {
-	case PitchAFlat, PitchBFlat, PitchDFlat, PitchEFlat, PitchGFlat:
+	case gohar.PitchAFlat, gohar.PitchBFlat, gohar.PitchDFlat, gohar.PitchEFlat, gohar.PitchGFlat:
 		return true
 	default:
 		return false
@@ -0,0 +1,294 @@
+// Package lilypond writes music in the LilyPond notation.
+//
+// See https://lilypond.org/doc/v2.24/Documentation/notation/ for the syntax.
+package lilypond
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+// A Language is a LilyPond note-name language.
+type Language int
+
+const (
+	// Dutch is the default language of LilyPond (cis, bes).
+	Dutch Language = iota
+	// English is the English language (cs, bf).
+	English
+)
+
+var languageNames = [...]string{"nederlands", "english"}
+
+// String returns the name of the language, as given to the \language command.
+func (l Language) String() string {
+	if l < 0 || int(l) >= len(languageNames) {
+		return "<invalid>"
+	}
+	return languageNames[l]
+}
+
+// Options tune how music is written.
+type Options struct {
+	Language Language
+	// Relative writes the octave of each note relative to the previous note,
+	// as in a \relative c' { ... } block, instead of absolute octaves.
+	Relative bool
+}
+
+// ScaleToLilyPond returns the notes of the scale upwards from given root, which
+// is written in the octave of middle C (so that C♭ is written as "ces'").
+func ScaleToLilyPond(root gohar.PitchClass, pattern gohar.ScalePattern, opts Options) string {
+	tonic := gohar.Note{PitchClass: root}
+	tonic.Oct -= tonic.BaseOctave()
+	return NotesToLilyPond(slices.Collect(pattern.Notes(tonic)), opts)
+}
+
+// NotesToLilyPond returns the notes one after the other. Relative octaves
+// start from middle C.
+func NotesToLilyPond(notes []gohar.Note, opts Options) string {
+	w := newWriter(opts)
+	names := make([]string, len(notes))
+	for i, n := range notes {
+		names[i] = w.note(n)
+	}
+	return strings.Join(names, " ")
+}
+
+// ChordToLilyPond returns the notes of the chord, with its root at given
+// octave (see [gohar.Chord.Notes]), as a LilyPond chord such as "<c' e' g'>".
+// Relative octaves start from middle C.
+func ChordToLilyPond(c gohar.Chord, oct int8, opts Options) string {
+	return newWriter(opts).chord(slices.Collect(c.Notes(oct)))
+}
+
+// ChordsToLilyPond returns the chords one after the other, with their roots at
+// given octave. Relative octaves start from middle C.
+func ChordsToLilyPond(chords []gohar.Chord, oct int8, opts Options) string {
+	w := newWriter(opts)
+	names := make([]string, len(chords))
+	for i, c := range chords {
+		names[i] = w.chord(slices.Collect(c.Notes(oct)))
+	}
+	return strings.Join(names, " ")
+}
+
+// NoteToLilyPond returns the note with an absolute octave: c is the C below
+// middle C, c' is middle C and c, is the C an octave below c.
+func NoteToLilyPond(n gohar.Note, lang Language) string {
+	return PitchClassToLilyPond(n.PitchClass, lang) + octaveMarks(int(n.BaseOctave())+1)
+}
+
+// RelativeNoteToLilyPond returns the note with an octave relative to the
+// previous note: without octave marks, a note is the closest one to the
+// previous note (within a fourth), regardless of alterations.
+func RelativeNoteToLilyPond(n, prev gohar.Note, lang Language) string {
+	diff := staffStep(n) - staffStep(prev)
+	// floored division, so that intervals up to a fourth get no marks
+	marks := (diff + 3) / 7
+	if diff+3 < 0 && (diff+3)%7 != 0 {
+		marks--
+	}
+	return PitchClassToLilyPond(n.PitchClass, lang) + octaveMarks(marks)
+}
+
+// staffStep returns the position of the note's base on the staff, in steps
+// from middle C.
+func staffStep(n gohar.Note) int {
+	return 7*int(n.BaseOctave()) + int(n.Base())
+}
+
+func octaveMarks(n int) string {
+	if n < 0 {
+		return strings.Repeat(",", -n)
+	}
+	return strings.Repeat("'", n)
+}
+
+var (
+	dutchAlts   = map[gohar.Pitch]string{-2: "eses", -1: "es", 1: "is", 2: "isis"}
+	englishAlts = map[gohar.Pitch]string{-2: "ff", -1: "f", 1: "s", 2: "ss"}
+)
+
+// PitchClassToLilyPond returns the name of the pitch class in given language.
+func PitchClassToLilyPond(pc gohar.PitchClass, lang Language) string {
+	name := gohar.LocaleEnglish.NoteNames[pc.Base()]
+	if lang == English {
+		return name + englishAlts[pc.Alt()]
+	}
+	alt := dutchAlts[pc.Alt()]
+	// as and es rather than aes and ees
+	if (name == "a" || name == "e") && pc.Alt() < 0 {
+		alt = alt[1:]
+	}
+	return name + alt
+}
+
+// KeyToLilyPond returns the key signature command of the key, such as
+// "\key d \major". Harmonic and melodic minor keys are written as minor keys.
+func KeyToLilyPond(k gohar.Key, lang Language) string {
+	return fmt.Sprintf(`\key %s \%s`, PitchClassToLilyPond(k.Root, lang), k.Mode())
+}
+
+// ChordSymbolToLilyPond returns the chord as a chord-mode symbol, such as
+// "c:m7.5-" or "f:7/a".
+func ChordSymbolToLilyPond(c gohar.Chord, lang Language) string {
+	symbol := PitchClassToLilyPond(c.Root, lang)
+	if m := chordModifiers(c.Pattern); m != "" {
+		symbol += ":" + m
+	}
+	if c.HasBass() {
+		symbol += "/" + PitchClassToLilyPond(c.Bass, lang)
+	}
+	return symbol
+}
+
+// chordModifiers returns the chord-mode modifiers of a chord pattern: a quality
+// and a chord number that implies the usual degrees (the 13th chord omitting
+// the 11th), followed by added or altered degrees, and removed degrees.
+func chordModifiers(c gohar.ChordPattern) string {
+	c = c.Unpack().FoldFlatThirteenth()
+	switch c {
+	case gohar.ChordPatternMajor:
+		return ""
+	case gohar.ChordPatternDiminished:
+		return "dim"
+	case gohar.ChordPatternDiminished7:
+		return "dim7"
+	case gohar.ChordPatternAugmented:
+		return "aug"
+	case gohar.ChordPatternAugmented.Add(gohar.PitchDiffMinorSeventh):
+		return "aug7"
+	case 0b000010000001:
+		return "1.5"
+	}
+	has := c.HasDegree
+	var (
+		quality, sus string
+		number       int
+		steps        []string
+		removed      []string
+	)
+	switch {
+	case has(gohar.PitchDiffMajorThird):
+	case has(gohar.PitchDiffMinorThird):
+		quality = "m"
+	case has(gohar.PitchDiffPerfectFourth):
+		sus = "sus4"
+	case has(gohar.PitchDiffMajorSecond):
+		sus = "sus2"
+	default:
+		removed = append(removed, "3")
+	}
+
+	switch {
+	case has(gohar.PitchDiffMinorSeventh):
+		number = 7
+	case has(gohar.PitchDiffMajorSeventh):
+		number = 7
+		if quality == "" {
+			quality = "maj"
+		} else {
+			steps = append(steps, "7+")
+		}
+	case has(gohar.PitchDiffMajorSixth):
+		number = 6
+	}
+	if number == 7 && has(gohar.PitchDiffMajorNinth) {
+		number = 9
+		switch {
+		case has(gohar.PitchDiffMajorThirteenth):
+			number = 13
+		case has(gohar.PitchDiffPerfectEleventh):
+			number = 11
+		}
+	}
+
+	switch {
+	case has(gohar.PitchDiffPerfectFifth):
+	case has(gohar.PitchDiffDiminishedFifth):
+		steps = append(steps, "5-")
+	case has(gohar.PitchDiffAugmentedFifth):
+		steps = append(steps, "5+")
+	default:
+		removed = append(removed, "5")
+	}
+	extensions := []struct {
+		pitch   gohar.Pitch
+		step    string
+		implied bool
+	}{
+		{gohar.PitchDiffMinorNinth, "9-", false},
+		{gohar.PitchDiffMajorNinth, "9", number >= 9},
+		{gohar.PitchDiffAugmentedNinth, "9+", false},
+		{gohar.PitchDiffPerfectEleventh, "11", number == 11},
+		{gohar.PitchDiffAugmentedEleventh, "11+", false},
+		{gohar.PitchDiffMinorThirteenth, "13-", false},
+		{gohar.PitchDiffMajorThirteenth, "13", number == 13},
+	}
+	for _, ext := range extensions {
+		if has(ext.pitch) && !ext.implied {
+			steps = append(steps, ext.step)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(quality)
+	if number > 0 {
+		fmt.Fprint(&sb, number)
+	} else if len(steps) > 0 {
+		// the triad must be explicit for steps to be added to it
+		sb.WriteString("5")
+	}
+	sb.WriteString(sus)
+	for _, step := range steps {
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(step)
+	}
+	if len(removed) > 0 {
+		sb.WriteString("^" + strings.Join(removed, "."))
+	}
+	return sb.String()
+}
+
+// A writer writes notes and chords, keeping track of the previous note for
+// relative octaves.
+type writer struct {
+	Options
+	prev gohar.Note
+}
+
+func newWriter(opts Options) *writer {
+	return &writer{Options: opts, prev: gohar.NoteC}
+}
+
+func (w *writer) note(n gohar.Note) string {
+	if !w.Relative {
+		return NoteToLilyPond(n, w.Language)
+	}
+	s := RelativeNoteToLilyPond(n, w.prev, w.Language)
+	w.prev = n
+	return s
+}
+
+// chord writes notes played together. In relative mode, each note is relative
+// to the previous note of the chord, and the next note is relative to the
+// first note of the chord.
+func (w *writer) chord(notes []gohar.Note) string {
+	if len(notes) == 0 {
+		return "<>"
+	}
+	names := make([]string, len(notes))
+	for i, n := range notes {
+		names[i] = w.note(n)
+	}
+	if w.Relative {
+		w.prev = notes[0]
+	}
+	return "<" + strings.Join(names, " ") + ">"
+}
@@ -0,0 +1,117 @@
+package lilypond
+
+import (
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestScaleToLilyPond(t *testing.T) {
+	testCases := []struct {
+		Root    gohar.PitchClass
+		Pattern gohar.ScalePattern
+		Options Options
+		Want    string
+	}{
+		{
+			gohar.PitchClassD, gohar.ScalePatternMajor, Options{},
+			"d' e' fis' g' a' b' cis''",
+		},
+		{
+			gohar.PitchClassB.Sharp(), gohar.ScalePatternMajor, Options{},
+			"bis' cisis'' disis'' eis'' fisis'' gisis'' aisis''",
+		},
+		{
+			gohar.PitchClassC.Flat(), gohar.ScalePatternMajor, Options{},
+			"ces' des' es' fes' ges' as' bes'",
+		},
+		{
+			gohar.PitchClassC.Sharp(), gohar.ScalePatternMajor, Options{},
+			"cis' dis' eis' fis' gis' ais' bis'",
+		},
+		{
+			gohar.PitchClassC.Flat(), gohar.ScalePatternMajor, Options{Language: English},
+			"cf' df' ef' ff' gf' af' bf'",
+		},
+		{
+			gohar.PitchClassB.Sharp(), gohar.ScalePatternMajor, Options{Relative: true},
+			"bis' cisis disis eis fisis gisis aisis",
+		},
+		{
+			gohar.PitchClassA, gohar.ScalePatternNaturalMinor, Options{Relative: true},
+			"a' b c d e f g",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Root.String(), func(t *testing.T) {
+			Expect(t, Equal(tc.Want, ScaleToLilyPond(tc.Root, tc.Pattern, tc.Options)))
+		})
+	}
+}
+
+func TestChordSymbolToLilyPond(t *testing.T) {
+	testCases := []struct {
+		Symbol   string
+		Language Language
+		Want     string
+	}{
+		{"C", Dutch, "c"},
+		{"Cm", Dutch, "c:m"},
+		{"Bbmaj7", Dutch, "bes:maj7"},
+		{"Bbmaj7", English, "bf:maj7"},
+		{"F7/A", Dutch, "f:7/a"},
+		{"Ebm7b5", Dutch, "es:m7.5-"},
+		{"Bdim7", Dutch, "b:dim7"},
+		{"Gaug", Dutch, "g:aug"},
+		{"Dsus4", Dutch, "d:sus4"},
+		{"C6", Dutch, "c:6"},
+		{"Cmmaj7", Dutch, "c:m7.7+"},
+		{"G13", Dutch, "g:13"},
+		{"G7b9", Dutch, "g:7.9-"},
+		{"G7b13", Dutch, "g:7.13-"},
+		{"C5", Dutch, "c:1.5"},
+		{"Cadd9", Dutch, "c:5.9"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Symbol, func(t *testing.T) {
+			c, err := gohar.ParseChord(tc.Symbol)
+			Require(t, NoError(err))
+			Expect(t, Equal(tc.Want, ChordSymbolToLilyPond(c, tc.Language)))
+		})
+	}
+}
+
+func TestKeyToLilyPond(t *testing.T) {
+	testCases := []struct {
+		Key      gohar.Key
+		Language Language
+		Want     string
+	}{
+		{gohar.NewMajorKey(gohar.PitchClassD), Dutch, `\key d \major`},
+		{gohar.NewMinorKey(gohar.PitchClassE.Flat()), Dutch, `\key es \minor`},
+		{gohar.NewMinorKey(gohar.PitchClassE.Flat()), English, `\key ef \minor`},
+		{gohar.NewMajorKey(gohar.PitchClassF.Sharp()), English, `\key fs \major`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Want, func(t *testing.T) {
+			Expect(t, Equal(tc.Want, KeyToLilyPond(tc.Key, tc.Language)))
+		})
+	}
+}
+
+func TestChordsToLilyPond(t *testing.T) {
+	chords := []gohar.Chord{
+		{Root: gohar.PitchClassC, Pattern: gohar.ChordPatternMajor},
+		{Root: gohar.PitchClassF, Pattern: gohar.ChordPatternMajor},
+		{Root: gohar.PitchClassG, Pattern: gohar.ChordPattern7},
+	}
+	Expect(t,
+		Equal("<c' e' g'> <f' a' c''> <g' b' d'' f''>", ChordsToLilyPond(chords, 0, Options{})),
+		Equal("<c e g> <f a c> <g b d f>", ChordsToLilyPond(chords, 0, Options{Relative: true})),
+		Equal("<c, e, g,>", ChordToLilyPond(chords[0], -2, Options{})),
+	)
+}
@@ -0,0 +1,160 @@
+package lilypond
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+// A Score is a single-staff score, with chord symbols above the staff if any
+// event has one.
+type Score struct {
+	// Title is the title of the work, if any.
+	Title string
+	// Key is the key signature, if any.
+	Key *gohar.Key
+	// Music is written as a single voice (see [gohar.Sequence.Voice]). The
+	// chord symbols of its events last until the next one. Its time signature
+	// is 4/4 if zero.
+	Music gohar.Sequence
+}
+
+// ScoreToLilyPond returns the score as a \score block. English note names are
+// selected with a \language command before the block.
+//
+// gohar.ErrInvalidTimeSignature is returned if the time signature is invalid,
+// and gohar.ErrInvalidSequence if the music isn't a single voice, or has
+// durations that can't be written as notes, such as those of tuplets.
+func ScoreToLilyPond(s Score, opts Options) (string, error) {
+	ts := cmp.Or(s.Music.TimeSignature, gohar.DefaultTimeSignature)
+	if _, err := gohar.NewTimeSignature(ts.Beats, ts.BeatType); err != nil {
+		return "", err
+	}
+	voice, err := s.Music.Voice()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		w        = newWriter(opts)
+		music    []string
+		harmony  []string
+		bar      = 1
+		symbol   string         // last chord symbol
+		symbolAt gohar.Duration // duration of the last chord symbol
+		spacer   gohar.Duration // duration before the first chord symbol
+	)
+	flushHarmony := func() {
+		switch {
+		case symbol != "":
+			harmony = append(harmony, chordModeDuration(symbol, symbolAt))
+		case !spacer.IsZero():
+			harmony = append(harmony, "s"+multipliedDuration(spacer))
+		}
+	}
+	for _, e := range voice.Events {
+		if e.Duration.IsZero() {
+			continue
+		}
+		if e.Harmony != nil {
+			flushHarmony()
+			symbol, symbolAt = ChordSymbolToLilyPond(*e.Harmony, opts.Language), gohar.Duration{}
+		}
+		if symbol == "" {
+			spacer = spacer.Add(e.Duration)
+		} else {
+			symbolAt = symbolAt.Add(e.Duration)
+		}
+		for ; bar < ts.Position(e.Start).Bar; bar++ {
+			music = append(music, "|")
+		}
+		// durations that have no note value are split into tied notes
+		values := e.Duration.NoteValues()
+		for i, v := range values {
+			d, ok := noteDuration(v)
+			if !ok {
+				return "", fmt.Errorf("%w: duration %s at %s can't be written", gohar.ErrInvalidSequence, v, e.Start)
+			}
+			var n string
+			switch len(e.Notes) {
+			case 0:
+				n = "r"
+			case 1:
+				n = w.note(e.Notes[0])
+			default:
+				n = w.chord(e.Notes)
+			}
+			n += d
+			if (i < len(values)-1 || e.Tied) && !e.IsRest() {
+				n += "~"
+			}
+			music = append(music, n)
+		}
+	}
+	if symbol != "" {
+		flushHarmony()
+	}
+
+	var sb strings.Builder
+	if opts.Language != Dutch {
+		fmt.Fprintf(&sb, "\\language \"%s\"\n\n", opts.Language)
+	}
+	sb.WriteString("\\score {\n  <<\n")
+	if len(harmony) > 0 {
+		fmt.Fprintf(&sb, "    \\new ChordNames \\chordmode { %s }\n", strings.Join(harmony, " "))
+	}
+	sb.WriteString("    \\new Staff {\n")
+	if s.Key != nil {
+		key := *s.Key
+		if key.IsTheoretical() {
+			key = key.Enharmonic()
+		}
+		fmt.Fprintf(&sb, "      %s\n", KeyToLilyPond(key, opts.Language))
+	}
+	fmt.Fprintf(&sb, "      \\time %s\n", ts)
+	body := strings.Join(music, " ")
+	if opts.Relative {
+		body = `\relative c' { ` + body + " }"
+	}
+	if len(music) > 0 {
+		fmt.Fprintf(&sb, "      %s\n", body)
+	}
+	sb.WriteString("    }\n  >>\n")
+	if s.Title != "" {
+		fmt.Fprintf(&sb, "  \\header { title = %q }\n", s.Title)
+	}
+	sb.WriteString("  \\layout { }\n}\n")
+	return sb.String(), nil
+}
+
+// noteDuration returns the LilyPond duration of a note lasting given duration,
+// such as "4." for a dotted quarter note.
+func noteDuration(d gohar.Duration) (string, bool) {
+	value, dots, ok := d.Undotted()
+	if !ok || value.Num() != 1 {
+		return "", false
+	}
+	return strconv.Itoa(value.Den()) + strings.Repeat(".", dots), true
+}
+
+// multipliedDuration returns a duration that may not be written as a single
+// note, such as "4*5" for five quarter notes.
+func multipliedDuration(d gohar.Duration) string {
+	if s, ok := noteDuration(d); ok {
+		return s
+	}
+	return fmt.Sprintf("%d*%d", d.Den(), d.Num())
+}
+
+// chordModeDuration inserts a duration into a chord-mode symbol, between its
+// root and its modifiers or bass.
+func chordModeDuration(symbol string, d gohar.Duration) string {
+	i := strings.IndexAny(symbol, ":/")
+	if i < 0 {
+		i = len(symbol)
+	}
+	return symbol[:i] + multipliedDuration(d) + symbol[i:]
+}
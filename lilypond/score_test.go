@@ -0,0 +1,157 @@
+package lilypond
+
+import (
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestScoreToLilyPond(t *testing.T) {
+	progression, err := gohar.ParseProgression("| Dm7 G7/B | C |", 2)
+	Require(t, NoError(err))
+	fMajor := gohar.NewMajorKey(gohar.PitchClassF)
+	aSharpMinor := gohar.NewMinorKey(gohar.PitchClassA.Sharp())
+
+	testCases := []struct {
+		Name    string
+		Score   func() Score
+		Options Options
+		Want    string
+	}{
+		{
+			"empty",
+			func() Score { return Score{} },
+			Options{},
+			"\\score {\n" +
+				"  <<\n" +
+				"    \\new Staff {\n" +
+				"      \\time 4/4\n" +
+				"    }\n" +
+				"  >>\n" +
+				"  \\layout { }\n" +
+				"}\n",
+		},
+		{
+			"notes",
+			func() Score {
+				s := gohar.Sequence{TimeSignature: gohar.TimeSignatureWaltz}
+				s.Append(gohar.DurationQuarter)
+				s.Append(gohar.DurationHalf, gohar.NoteB.Flat().Octave(-1))
+				s.Append(gohar.DurationQuarter.Dotted(1), gohar.NoteF.Sharp().Octave(1))
+				s.Append(gohar.DurationEighth, gohar.NoteC.Octave(-2))
+				return Score{Title: "Notes", Key: &fMajor, Music: s}
+			},
+			Options{},
+			"\\score {\n" +
+				"  <<\n" +
+				"    \\new Staff {\n" +
+				"      \\key f \\major\n" +
+				"      \\time 3/4\n" +
+				"      r4 bes2 | fis''4. c,8\n" +
+				"    }\n" +
+				"  >>\n" +
+				"  \\header { title = \"Notes\" }\n" +
+				"  \\layout { }\n" +
+				"}\n",
+		},
+		{
+			"ties and gaps",
+			func() Score {
+				s := gohar.Sequence{TimeSignature: gohar.TimeSignature{Beats: 2, BeatType: 4}}
+				s.Events = []gohar.Event{
+					{Notes: []gohar.Note{gohar.NoteE}, Start: gohar.DurationEighth, Duration: gohar.DurationHalf.Add(gohar.DurationSixteenth)},
+					{Notes: []gohar.Note{gohar.NoteE, gohar.NoteG}, Start: gohar.DurationHalf.Dotted(1), Duration: gohar.DurationQuarter, Tied: true},
+				}
+				return Score{Music: s}
+			},
+			Options{},
+			"\\score {\n" +
+				"  <<\n" +
+				"    \\new Staff {\n" +
+				"      \\time 2/4\n" +
+				"      r8 e'4.~ | e'8. r16 <e' g'>4~\n" +
+				"    }\n" +
+				"  >>\n" +
+				"  \\layout { }\n" +
+				"}\n",
+		},
+		{
+			"chord symbols",
+			func() Score {
+				s := progression.Sequence(0, gohar.TimeSignature{Beats: 2, BeatType: 4})
+				// a melody note before the first chord symbol, then a held chord
+				s.Events = append([]gohar.Event{{Notes: []gohar.Note{gohar.NoteA}, Duration: gohar.DurationHalf}}, s.Events...)
+				for i := 1; i < len(s.Events); i++ {
+					s.Events[i].Start = s.Events[i].Start.Add(gohar.DurationHalf)
+				}
+				s.Append(gohar.DurationHalf.Dotted(1), gohar.NoteE)
+				return Score{Music: s}
+			},
+			Options{},
+			"\\score {\n" +
+				"  <<\n" +
+				"    \\new ChordNames \\chordmode { s2 d4:m7 g4:7/b c4*5 }\n" +
+				"    \\new Staff {\n" +
+				"      \\time 2/4\n" +
+				"      a'2 | <d' f' a' c''>4 <b g' d'' f''>4 | <c' e' g'>2 | e'2~ | e'4\n" +
+				"    }\n" +
+				"  >>\n" +
+				"  \\layout { }\n" +
+				"}\n",
+		},
+		{
+			"english and relative",
+			func() Score {
+				var s gohar.Sequence
+				s.AppendChord(gohar.DurationHalf, gohar.Chord{Root: gohar.PitchClassB.Flat(), Pattern: gohar.ChordPatternMajor7}, -1)
+				s.Append(gohar.DurationHalf, gohar.NoteE.Flat())
+				s.Append(gohar.DurationWhole, gohar.NoteF.Sharp().Octave(-1))
+				return Score{Key: &aSharpMinor, Music: s}
+			},
+			Options{Language: English, Relative: true},
+			"\\language \"english\"\n" +
+				"\n" +
+				"\\score {\n" +
+				"  <<\n" +
+				"    \\new ChordNames \\chordmode { bf1*2:maj7 }\n" +
+				"    \\new Staff {\n" +
+				"      \\key as \\minor\n" +
+				"      \\time 4/4\n" +
+				"      \\relative c' { <bf d f a>2 ef2 | fs,1 }\n" +
+				"    }\n" +
+				"  >>\n" +
+				"  \\layout { }\n" +
+				"}\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := ScoreToLilyPond(tc.Score(), tc.Options)
+			Expect(t, NoError(err), Equal(tc.Want, got))
+		})
+	}
+}
+
+func TestScoreToLilyPondInvalid(t *testing.T) {
+	var triplets gohar.Sequence
+	triplets.Append(gohar.DurationEighth.Tuplet(3, 2), gohar.NoteC)
+
+	testCases := []struct {
+		Name  string
+		Music gohar.Sequence
+		Want  error
+	}{
+		{"time signature", gohar.Sequence{TimeSignature: gohar.TimeSignature{Beats: 0, BeatType: 4}}, gohar.ErrInvalidTimeSignature},
+		{"negative duration", gohar.Sequence{Events: []gohar.Event{{Duration: gohar.NewDuration(-1, 4)}}}, gohar.ErrInvalidSequence},
+		{"tuplets", triplets, gohar.ErrInvalidSequence},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ScoreToLilyPond(Score{Music: tc.Music}, Options{})
+			Expect(t, IsError(tc.Want, err))
+		})
+	}
+}
@@ -2,6 +2,10 @@ package gohar
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // A Locale is responsible for producing string representations
@@ -9,29 +13,148 @@ import (
 type Locale struct {
 	NoteNames  []string
 	ScaleNames map[ScalePattern]string
+	ChordStyle ChordStyle
+
+	// IntervalNumbers are the names of interval numbers, starting with the unison.
+	IntervalNumbers []string
+	// IntervalQualities are the names of interval qualities.
+	IntervalQualities map[IntervalQuality]string
+	// IntervalFormat is used to build an interval's name from its quality (first argument)
+	// and its number (second argument).
+	IntervalFormat string
 }
 
 var (
 	LocaleFrench = Locale{
 		NoteNames: []string{"do", "ré", "mi", "fa", "sol", "la", "si"},
 		ScaleNames: map[ScalePattern]string{
-			ScalePatternMajor:               "majeur",
-			ScalePatternMelodicMinor:        "mineur mélodique",
-			ScalePatternHarmonicMinor:       "mineur harmonique",
-			ScalePatternHarmonicMajor:       "majeur harmonique",
-			ScalePatternDoubleHarmonicMajor: "majeur double harmonique",
+			ScalePatternMajor:                 "majeur",
+			ScalePatternDorian:                "dorien",
+			ScalePatternPhrygian:              "phrygien",
+			ScalePatternLydian:                "lydien",
+			ScalePatternMixolydian:            "mixolydien",
+			ScalePatternNaturalMinor:          "mineur naturel",
+			ScalePatternLocrian:               "locrien",
+			ScalePatternMelodicMinor:          "mineur mélodique",
+			ScalePatternDorianFlat2:           "dorien ♭2",
+			ScalePatternLydianAugmented:       "lydien augmenté",
+			ScalePatternLydianDominant:        "lydien dominant",
+			ScalePatternMixolydianFlat6:       "mixolydien ♭6",
+			ScalePatternLocrianNatural2:       "locrien ♮2",
+			ScalePatternAltered:               "altéré",
+			ScalePatternHarmonicMinor:         "mineur harmonique",
+			ScalePatternLocrianNatural6:       "locrien ♮6",
+			ScalePatternIonianAugmented:       "ionien augmenté",
+			ScalePatternDorianSharp4:          "dorien ♯4",
+			ScalePatternPhrygianDominant:      "phrygien dominant",
+			ScalePatternLydianSharp2:          "lydien ♯2",
+			ScalePatternAlteredDiminished:     "altéré diminué",
+			ScalePatternHarmonicMajor:         "majeur harmonique",
+			ScalePatternDorianFlat5:           "dorien ♭5",
+			ScalePatternPhrygianFlat4:         "phrygien ♭4",
+			ScalePatternLydianFlat3:           "lydien ♭3",
+			ScalePatternMixolydianFlat2:       "mixolydien ♭2",
+			ScalePatternLydianAugmentedSharp2: "lydien augmenté ♯2",
+			ScalePatternLocrianDiminished7:    "locrien 𝄫7",
+			ScalePatternDoubleHarmonicMajor:   "majeur double harmonique",
+			ScalePatternMajorPentatonic:       "pentatonique majeur",
+			ScalePatternMinorPentatonic:       "pentatonique mineur",
+			ScalePatternBlues:                 "blues",
+			ScalePatternMajorBlues:            "blues majeur",
+			ScalePatternBebopDominant:         "bebop dominant",
+			ScalePatternBebopMajor:            "bebop majeur",
+			ScalePatternBebopDorian:           "bebop dorien",
+			ScalePatternWholeTone:             "par tons",
+			ScalePatternDiminishedHalfWhole:   "diminué demi-ton/ton",
+			ScalePatternDiminishedWholeHalf:   "diminué ton/demi-ton",
+			ScalePatternAugmented:             "augmenté",
+			ScalePatternMessiaen3:             "mode 3 de Messiaen",
+			ScalePatternMessiaen4:             "mode 4 de Messiaen",
+			ScalePatternMessiaen5:             "mode 5 de Messiaen",
+			ScalePatternMessiaen6:             "mode 6 de Messiaen",
+			ScalePatternMessiaen7:             "mode 7 de Messiaen",
+		},
+		ChordStyle: ChordStyleStandard,
+		IntervalNumbers: []string{
+			"unisson", "seconde", "tierce", "quarte", "quinte", "sixte", "septième", "octave",
+			"neuvième", "dixième", "onzième", "douzième", "treizième", "quatorzième", "quinzième",
 		},
+		IntervalQualities: map[IntervalQuality]string{
+			QualityDoublyDiminished: "doublement diminuée",
+			QualityDiminished:       "diminuée",
+			QualityMinor:            "mineure",
+			QualityPerfect:          "juste",
+			QualityMajor:            "majeure",
+			QualityAugmented:        "augmentée",
+			QualityDoublyAugmented:  "doublement augmentée",
+		},
+		IntervalFormat: "%[2]s %[1]s",
 	}
 
 	LocaleEnglish = Locale{
 		NoteNames: []string{"c", "d", "e", "f", "g", "a", "b"},
 		ScaleNames: map[ScalePattern]string{
-			ScalePatternMajor:               "major",
-			ScalePatternMelodicMinor:        "melodic minor",
-			ScalePatternHarmonicMinor:       "harmonic minor",
-			ScalePatternHarmonicMajor:       "harmonic major",
-			ScalePatternDoubleHarmonicMajor: "double harmonic major",
+			ScalePatternMajor:                 "major",
+			ScalePatternDorian:                "dorian",
+			ScalePatternPhrygian:              "phrygian",
+			ScalePatternLydian:                "lydian",
+			ScalePatternMixolydian:            "mixolydian",
+			ScalePatternNaturalMinor:          "natural minor",
+			ScalePatternLocrian:               "locrian",
+			ScalePatternMelodicMinor:          "melodic minor",
+			ScalePatternDorianFlat2:           "dorian ♭2",
+			ScalePatternLydianAugmented:       "lydian augmented",
+			ScalePatternLydianDominant:        "lydian dominant",
+			ScalePatternMixolydianFlat6:       "mixolydian ♭6",
+			ScalePatternLocrianNatural2:       "locrian ♮2",
+			ScalePatternAltered:               "altered",
+			ScalePatternHarmonicMinor:         "harmonic minor",
+			ScalePatternLocrianNatural6:       "locrian ♮6",
+			ScalePatternIonianAugmented:       "ionian augmented",
+			ScalePatternDorianSharp4:          "dorian ♯4",
+			ScalePatternPhrygianDominant:      "phrygian dominant",
+			ScalePatternLydianSharp2:          "lydian ♯2",
+			ScalePatternAlteredDiminished:     "altered diminished",
+			ScalePatternHarmonicMajor:         "harmonic major",
+			ScalePatternDorianFlat5:           "dorian ♭5",
+			ScalePatternPhrygianFlat4:         "phrygian ♭4",
+			ScalePatternLydianFlat3:           "lydian ♭3",
+			ScalePatternMixolydianFlat2:       "mixolydian ♭2",
+			ScalePatternLydianAugmentedSharp2: "lydian augmented ♯2",
+			ScalePatternLocrianDiminished7:    "locrian 𝄫7",
+			ScalePatternDoubleHarmonicMajor:   "double harmonic major",
+			ScalePatternMajorPentatonic:       "major pentatonic",
+			ScalePatternMinorPentatonic:       "minor pentatonic",
+			ScalePatternBlues:                 "blues",
+			ScalePatternMajorBlues:            "major blues",
+			ScalePatternBebopDominant:         "bebop dominant",
+			ScalePatternBebopMajor:            "bebop major",
+			ScalePatternBebopDorian:           "bebop dorian",
+			ScalePatternWholeTone:             "whole tone",
+			ScalePatternDiminishedHalfWhole:   "half-whole diminished",
+			ScalePatternDiminishedWholeHalf:   "whole-half diminished",
+			ScalePatternAugmented:             "augmented",
+			ScalePatternMessiaen3:             "Messiaen mode 3",
+			ScalePatternMessiaen4:             "Messiaen mode 4",
+			ScalePatternMessiaen5:             "Messiaen mode 5",
+			ScalePatternMessiaen6:             "Messiaen mode 6",
+			ScalePatternMessiaen7:             "Messiaen mode 7",
+		},
+		ChordStyle: ChordStyleStandard,
+		IntervalNumbers: []string{
+			"unison", "second", "third", "fourth", "fifth", "sixth", "seventh", "octave",
+			"ninth", "tenth", "eleventh", "twelfth", "thirteenth", "fourteenth", "fifteenth",
 		},
+		IntervalQualities: map[IntervalQuality]string{
+			QualityDoublyDiminished: "doubly diminished",
+			QualityDiminished:       "diminished",
+			QualityMinor:            "minor",
+			QualityPerfect:          "perfect",
+			QualityMajor:            "major",
+			QualityAugmented:        "augmented",
+			QualityDoublyAugmented:  "doubly augmented",
+		},
+		IntervalFormat: "%[1]s %[2]s",
 	}
 
 	CurrentLocale = &LocaleEnglish
@@ -61,6 +184,47 @@ func (loc *Locale) ScaleName(scale Scale) (string, error) {
 	return note + " " + name, errors.Join(noteErr, nameErr)
 }
 
+// ChordName returns the Chord's symbol in the current locale, e.g. "Cm7♭5/G" or "Dom7♭5/Sol".
+func (loc *Locale) ChordName(chord Chord) (string, error) {
+	root, err := loc.NoteName(chord.Root)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(capitalize(root))
+	b.WriteString(loc.ChordStyle.Name(chord.Pattern))
+	if chord.HasBass() {
+		bass, err := loc.NoteName(chord.Bass)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString("/" + capitalize(bass))
+	}
+	return b.String(), nil
+}
+
+// IntervalName returns the Interval's name in the current locale, e.g. "major third".
+// Descending intervals have the same name as their ascending counterparts.
+//
+// ErrInvalidInterval is returned if the interval has no valid quality, or if
+// its number has no name in the locale.
+func (loc *Locale) IntervalName(interval Interval) (string, error) {
+	quality, ok := loc.IntervalQualities[interval.Quality()]
+	if !ok {
+		return "", wrapErrorf(ErrInvalidInterval, "%s", interval)
+	}
+	n := interval.Number()
+	if n > len(loc.IntervalNumbers) {
+		return "", wrapErrorf(ErrInvalidInterval, "%s has no name", interval)
+	}
+	return fmt.Sprintf(loc.IntervalFormat, quality, loc.IntervalNumbers[n-1]), nil
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
 var ErrLocaleNotSet = errors.New("gohar.CurrentLocale is not set")
 
 // NoteName returns the Note's name in the current locale.
@@ -92,3 +256,23 @@ func ScaleName(scale Scale) (string, error) {
 	}
 	return "", ErrLocaleNotSet
 }
+
+// ChordName returns the Chord's symbol in the current locale.
+//
+// ErrLocaleNotSet is returned if the package's locale isn't set.
+func ChordName(chord Chord) (string, error) {
+	if CurrentLocale != nil {
+		return CurrentLocale.ChordName(chord)
+	}
+	return "", ErrLocaleNotSet
+}
+
+// IntervalName returns the Interval's name in the current locale.
+//
+// ErrLocaleNotSet is returned if the package's locale isn't set.
+func IntervalName(interval Interval) (string, error) {
+	if CurrentLocale != nil {
+		return CurrentLocale.IntervalName(interval)
+	}
+	return "", ErrLocaleNotSet
+}
@@ -27,3 +27,54 @@ func TestLocaleNoteName(t *testing.T) {
 		Expect(t, tc.Check(have, err))
 	}
 }
+
+func TestLocaleChordName(t *testing.T) {
+	isError := HasError[string]
+	isString := AsCheckFunc(func(a, b string) error {
+		return Equal(a, b)
+	})
+	testCases := []struct {
+		Loc   *Locale
+		Chord Chord
+		Check CheckFunc[string]
+	}{
+		{&LocaleEnglish, Chord{Root: 8}, isError(ErrInvalidPitchClass)},
+		{&LocaleEnglish, Chord{Root: PitchClassC, Pattern: ChordPatternMinor7Flat5, Bass: PitchClassG}, isString("Cm7♭5/G")},
+		{&LocaleFrench, Chord{Root: PitchClassD, Pattern: ChordPatternMinor7}, isString("Rém7")},
+		{&LocaleFrench, Chord{Root: PitchClassB.Flat(), Pattern: ChordPattern7, Bass: PitchClassD}, isString("Si" + AltFlat + "7/Ré")},
+		{&Locale{NoteNames: LocaleEnglish.NoteNames, ChordStyle: ChordStyleJazz}, Chord{Root: PitchClassC, Pattern: ChordPatternMajor7}, isString("CΔ7")},
+	}
+
+	for _, tc := range testCases {
+		have, err := tc.Loc.ChordName(tc.Chord)
+		Expect(t, tc.Check(have, err))
+	}
+}
+
+func TestLocaleIntervalName(t *testing.T) {
+	isError := HasError[string]
+	isString := AsCheckFunc(func(a, b string) error {
+		return Equal(a, b)
+	})
+	testCases := []struct {
+		Loc      *Locale
+		Interval Interval
+		Check    CheckFunc[string]
+	}{
+		{&LocaleEnglish, Interval{2, 0}, isError(ErrInvalidInterval)},
+		{&LocaleEnglish, Interval{15, 26}, isError(ErrInvalidInterval)},
+		{&LocaleEnglish, IntMajorThird, isString("major third")},
+		{&LocaleEnglish, IntPerfectFifth.Down(), isString("perfect fifth")},
+		{&LocaleEnglish, IntAugmentedEleventh, isString("augmented eleventh")},
+		{&LocaleEnglish, Interval{3, 3}, isString("doubly diminished fourth")},
+		{&LocaleFrench, IntMinorThird, isString("tierce mineure")},
+		{&LocaleFrench, IntPerfectFourth, isString("quarte juste")},
+		{&LocaleFrench, IntDiminishedSeventh, isString("septième diminuée")},
+		{&LocaleFrench, IntUnisson, isString("unisson juste")},
+	}
+
+	for _, tc := range testCases {
+		have, err := tc.Loc.IntervalName(tc.Interval)
+		Expect(t, tc.Check(have, err))
+	}
+}
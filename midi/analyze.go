@@ -0,0 +1,250 @@
+package midi
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+// A NoteEvent is a note played during a span of time.
+type NoteEvent struct {
+	Note gohar.Note
+	// Start and Duration are in ticks.
+	Start, Duration uint32
+	Channel         uint8
+	Velocity        uint8
+}
+
+// End returns the tick at which the note stops.
+func (n NoteEvent) End() uint32 {
+	return n.Start + n.Duration
+}
+
+// Key returns the first valid key signature of the file, if any.
+func (f *File) Key() (gohar.Key, bool) {
+	for _, t := range f.Tracks {
+		for _, e := range t.Events {
+			if m := e.Message; m.IsMeta() && m[1] == MetaKeySignature && len(m) >= 4 {
+				pattern := gohar.ScalePatternMajor
+				if m[3] == 1 {
+					pattern = gohar.ScalePatternNaturalMinor
+				}
+				if key, err := gohar.NewKeyFromSignature(int(int8(m[2])), pattern); err == nil {
+					return key, true
+				}
+			}
+		}
+	}
+	return gohar.Key{}, false
+}
+
+// TimeSignature returns the first valid time signature of the file, or 4/4 if
+// there is none.
+func (f *File) TimeSignature() gohar.TimeSignature {
+	for _, t := range f.Tracks {
+		for _, e := range t.Events {
+			if m := e.Message; m.IsMeta() && m[1] == MetaTimeSignature && len(m) >= 4 && m[3] < 8 {
+				if ts, err := gohar.NewTimeSignature(int(m[2]), 1<<m[3]); err == nil {
+					return ts
+				}
+			}
+		}
+	}
+	return gohar.TimeSignatureCommon
+}
+
+// Notes returns the notes of every track of the file, sorted by start time,
+// then by pitch. Note on events are paired with the next note off event (or
+// note on event with a null velocity) of the same key and channel.
+//
+// Notes are spelled after the key signature of the file, if any, or after
+// the major key that fits them best (see [gohar.Spell]).
+func (f *File) Notes() []NoteEvent {
+	var notes []NoteEvent
+	for _, t := range f.Tracks {
+		var (
+			playing = make(map[[2]uint8][]int) // indices of notes by channel and key
+			end     uint32
+		)
+		for _, e := range t.Events {
+			end = max(end, e.Tick)
+			m := e.Message
+			if len(m) < 3 || m.IsMeta() {
+				continue
+			}
+			channel, key := m[0]&0x0F, m[1]
+			switch m[0] & 0xF0 {
+			case 0x90:
+				if m[2] > 0 {
+					p, err := gohar.PitchFromMIDI(int(key))
+					if err != nil {
+						continue
+					}
+					playing[[2]uint8{channel, key}] = append(playing[[2]uint8{channel, key}], len(notes))
+					notes = append(notes, NoteEvent{
+						Note:     gohar.Note{PitchClass: gohar.DefaultPitchClass(p), Oct: p.GetOctave()},
+						Start:    e.Tick,
+						Channel:  channel,
+						Velocity: m[2],
+					})
+					continue
+				}
+				fallthrough
+			case 0x80:
+				if started := playing[[2]uint8{channel, key}]; len(started) > 0 {
+					notes[started[0]].Duration = e.Tick - notes[started[0]].Start
+					playing[[2]uint8{channel, key}] = started[1:]
+				}
+			}
+		}
+		// notes that are never released last until the end of the track
+		for _, started := range playing {
+			for _, i := range started {
+				notes[i].Duration = end - notes[i].Start
+			}
+		}
+	}
+	slices.SortStableFunc(notes, func(a, b NoteEvent) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.Note.Pitch(), b.Note.Pitch()))
+	})
+
+	pitches := make([]gohar.Pitch, len(notes))
+	for i, n := range notes {
+		pitches[i] = n.Note.Pitch()
+	}
+	var ctx gohar.SpellingContext
+	if key, ok := f.Key(); ok {
+		ctx.Scale = key.Scale
+	}
+	for i, n := range gohar.Spell(pitches, ctx) {
+		notes[i].Note = n
+	}
+	return notes
+}
+
+// Sequence returns the notes of the file (see [File.Notes]) as a sequence of
+// events, with the time signature of the file. Times are converted from ticks
+// to fractions of a whole note, and notes that start and stop together are
+// grouped in a single event.
+func (f *File) Sequence() gohar.Sequence {
+	whole := 4 * int(cmp.Or(f.TicksPerQuarter, DefaultTicksPerQuarter))
+	s := gohar.Sequence{TimeSignature: f.TimeSignature()}
+	for _, n := range f.Notes() {
+		start, duration := gohar.NewDuration(int(n.Start), whole), gohar.NewDuration(int(n.Duration), whole)
+		i := len(s.Events) - 1
+		for ; i >= 0 && s.Events[i].Start.Equal(start); i-- {
+			if s.Events[i].Duration.Equal(duration) {
+				s.Events[i].Notes = append(s.Events[i].Notes, n.Note)
+				break
+			}
+		}
+		if i < 0 || !s.Events[i].Start.Equal(start) {
+			s.Events = append(s.Events, gohar.Event{Notes: []gohar.Note{n.Note}, Start: start, Duration: duration})
+		}
+	}
+	return s
+}
+
+// A Segmentation is the way music is cut into segments for analysis.
+type Segmentation int
+
+const (
+	// ByBeat cuts the music at every beat of the time signature.
+	ByBeat Segmentation = iota
+	// ByBar cuts the music at every bar.
+	ByBar
+)
+
+// A Segment is a span of time of the analyzed music.
+type Segment struct {
+	// Start and End are the bounds of the segment, in ticks.
+	Start, End uint32
+	// Notes are the notes that sound during the segment.
+	Notes []NoteEvent
+	// Chord is the chord identified in the segment. Its root is invalid if
+	// fewer than three pitch classes sound during the segment.
+	Chord gohar.Chord
+	// Scale is the scale that fits the notes of the segment best. Its pattern
+	// is zero if no note sounds during the segment.
+	Scale gohar.Scale
+}
+
+// Analyze cuts the music into segments after its time signature, and
+// identifies the chord and the scale of each segment. The result is a timeline
+// of successive segments that covers every note of the file.
+//
+// Chords are identified with [gohar.IdentifyChords], and scales are found with
+// [gohar.FindScales], preferring scales built on the root of the chord (or on
+// the tonic of the key signature, if no chord is identified).
+func (f *File) Analyze(by Segmentation) []Segment {
+	notes := f.Notes()
+	if len(notes) == 0 {
+		return nil
+	}
+	tpq := uint32(f.TicksPerQuarter)
+	if tpq == 0 {
+		tpq = DefaultTicksPerQuarter
+	}
+	ts := f.TimeSignature()
+	length := tpq * 4 / uint32(ts.BeatType)
+	if by == ByBar {
+		length *= uint32(ts.Beats)
+	}
+	length = max(length, 1)
+	var end uint32
+	for _, n := range notes {
+		end = max(end, n.End())
+	}
+	key, hasKey := f.Key()
+
+	var segments []Segment
+	for start := uint32(0); start < end; start += length {
+		s := Segment{Start: start, End: start + length}
+		for _, n := range notes {
+			if n.Start < s.End && n.End() > s.Start {
+				s.Notes = append(s.Notes, n)
+			}
+		}
+		s.Chord = identifyChord(s.Notes)
+		opts := gohar.FindScalesOptions{Root: s.Chord.Root}
+		if !opts.Root.IsValid() && hasKey {
+			opts.Root = key.Root
+		}
+		pcs := make([]gohar.PitchClass, len(s.Notes))
+		for i, n := range s.Notes {
+			pcs[i] = n.Note.PitchClass
+		}
+		if scales := gohar.FindScales(pcs, opts); len(scales) > 0 {
+			s.Scale = scales[0]
+		}
+		segments = append(segments, s)
+	}
+	return segments
+}
+
+// identifyChord returns the simplest chord made of the notes, spelled after
+// them, or the zero Chord if there are fewer than three pitch classes.
+func identifyChord(notes []NoteEvent) gohar.Chord {
+	pitches := make([]gohar.Pitch, len(notes))
+	spelling := make(map[gohar.Pitch]gohar.PitchClass)
+	for i, n := range notes {
+		pitches[i] = n.Note.Pitch()
+		spelling[pitches[i].Normalize()] = n.Note.PitchClass
+	}
+	if len(spelling) < 3 {
+		return gohar.Chord{}
+	}
+	chord := gohar.IdentifyChords(pitches)[0].Chord
+	respell := func(pc gohar.PitchClass) gohar.PitchClass {
+		if spelled, ok := spelling[pc.Pitch(0).Normalize()]; ok {
+			return spelled
+		}
+		return pc
+	}
+	chord.Root = respell(chord.Root)
+	if chord.HasBass() {
+		chord.Bass = respell(chord.Bass)
+	}
+	return chord
+}
@@ -0,0 +1,192 @@
+package midi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+// Options tune the files built by [FromNotes], [FromScale], [FromChords],
+// [FromProgression] and [FromSequence]. Every field is optional.
+type Options struct {
+	// Format is the format of the file. Multitrack files have a first track
+	// with the tempo, time signature and key signature, and a second track with
+	// the notes. Single track files hold everything in one track.
+	Format Format
+	// TicksPerQuarter is the resolution of the file (DefaultTicksPerQuarter
+	// if zero).
+	TicksPerQuarter uint16
+	// Tempo is the tempo in quarter notes per minute (120 if zero), between
+	// MinTempo and MaxTempo.
+	Tempo float64
+	// TimeSignature is the time signature (4/4 if zero).
+	TimeSignature gohar.TimeSignature
+	// Key is the key signature, if any.
+	Key *gohar.Key
+	// Name is the name of the track holding the notes.
+	Name string
+	// Channel is the MIDI channel of the notes (0 to 15).
+	Channel uint8
+	// Velocity is the velocity of the notes (1 to 127, or 100 if zero).
+	Velocity uint8
+}
+
+// builder builds a file after the options.
+type builder struct {
+	Options
+	conductor, notes Track
+}
+
+func newBuilder(opts Options) (*builder, error) {
+	if opts.TicksPerQuarter == 0 {
+		opts.TicksPerQuarter = DefaultTicksPerQuarter
+	}
+	if opts.Tempo == 0 {
+		opts.Tempo = 120
+	}
+	opts.TimeSignature = cmp.Or(opts.TimeSignature, gohar.DefaultTimeSignature)
+	if opts.Velocity == 0 {
+		opts.Velocity = 100
+	}
+	switch {
+	case opts.Channel > 15:
+		return nil, fmt.Errorf("%w: channel %d", ErrInvalidOptions, opts.Channel)
+	case opts.Velocity > 127:
+		return nil, fmt.Errorf("%w: velocity %d", ErrInvalidOptions, opts.Velocity)
+	case !(opts.Tempo >= MinTempo && opts.Tempo <= MaxTempo):
+		return nil, fmt.Errorf("%w: tempo %g", ErrInvalidOptions, opts.Tempo)
+	}
+	b := &builder{Options: opts}
+	timeSignature, err := TimeSignature(opts.TimeSignature)
+	if err != nil {
+		return nil, err
+	}
+	b.conductor.Add(0, Tempo(opts.Tempo))
+	b.conductor.Add(0, timeSignature)
+	if opts.Key != nil {
+		b.conductor.Add(0, KeySignature(*opts.Key))
+	}
+	if opts.Name != "" {
+		b.notes.Add(0, TrackName(opts.Name))
+	}
+	return b, nil
+}
+
+// beats returns the number of ticks in given number of beats, a beat being the
+// unit of the time signature.
+func (b *builder) beats(n int) uint32 {
+	return uint32(n) * uint32(b.TicksPerQuarter) * 4 / uint32(b.TimeSignature.BeatType)
+}
+
+// ticks returns the number of ticks in given duration, rounded down.
+func (b *builder) ticks(d gohar.Duration) uint32 {
+	num, den := d.Div(gohar.DurationQuarter)
+	return uint32(num * int(b.TicksPerQuarter) / den)
+}
+
+func (b *builder) add(start, duration uint32, notes ...gohar.Note) error {
+	return b.notes.AddNotes(start, duration, b.Channel, b.Velocity, notes...)
+}
+
+func (b *builder) file() *File {
+	f := &File{Format: b.Format, TicksPerQuarter: b.TicksPerQuarter}
+	if b.Format == SingleTrack {
+		f.Tracks = []Track{{Events: append(b.conductor.Events, b.notes.Events...)}}
+	} else {
+		f.Tracks = []Track{b.conductor, b.notes}
+	}
+	return f
+}
+
+// FromNotes returns a file that plays the notes one after the other, each
+// lasting one beat.
+//
+// gohar.ErrInvalidMIDINote is returned if a note is out of the MIDI range,
+// gohar.ErrInvalidTimeSignature if the time signature is invalid, and
+// ErrInvalidOptions if the channel, the velocity or the tempo is out of range.
+func FromNotes(notes []gohar.Note, opts Options) (*File, error) {
+	b, err := newBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+	for i, n := range notes {
+		if err := b.add(b.beats(i), b.beats(1), n); err != nil {
+			return nil, err
+		}
+	}
+	return b.file(), nil
+}
+
+// FromScale returns a file that plays the scale upwards from given root, up to
+// the octave, each note lasting one beat. Errors are the same as [FromNotes].
+func FromScale(root gohar.Note, pattern gohar.ScalePattern, opts Options) (*File, error) {
+	notes := append(slices.Collect(pattern.Notes(root)), root.Transpose(gohar.IntOctave))
+	return FromNotes(notes, opts)
+}
+
+// FromChords returns a file that plays the chords one after the other, each
+// lasting a bar. The chords are laid out with their root at given octave (see
+// [gohar.Chord.Notes]). Errors are the same as [FromNotes].
+func FromChords(chords []gohar.Chord, oct int8, opts Options) (*File, error) {
+	b, err := newBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+	bar := b.beats(b.TimeSignature.Beats)
+	for i, c := range chords {
+		if err := b.add(uint32(i)*bar, bar, slices.Collect(c.Notes(oct))...); err != nil {
+			return nil, err
+		}
+	}
+	return b.file(), nil
+}
+
+// FromProgression returns a file that plays the chord progression, with the
+// chords laid out with their root at given octave (see [gohar.Chord.Notes]).
+// Unless opts.TimeSignature is set, the time signature has as many quarter
+// notes as the bars of the progression have beats. Errors are the same as
+// [FromNotes].
+func FromProgression(p gohar.Progression, oct int8, opts Options) (*File, error) {
+	if opts.TimeSignature == (gohar.TimeSignature{}) && p.BeatsPerBar > 0 {
+		opts.TimeSignature = gohar.TimeSignature{Beats: p.BeatsPerBar, BeatType: 4}
+	}
+	b, err := newBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+	var start uint32
+	for c := range p.All() {
+		duration := b.beats(c.Beats)
+		if err := b.add(start, duration, slices.Collect(c.Notes(oct))...); err != nil {
+			return nil, err
+		}
+		start += duration
+	}
+	return b.file(), nil
+}
+
+// FromSequence returns a file that plays the events of the sequence, with the
+// time signature of the sequence unless opts.TimeSignature is set. Tied events
+// are played as single notes, and events are rounded down to the nearest tick.
+// Errors are the same as [FromNotes], and gohar.ErrInvalidSequence is also
+// returned if an event has a negative start or duration.
+func FromSequence(s gohar.Sequence, opts Options) (*File, error) {
+	if opts.TimeSignature == (gohar.TimeSignature{}) {
+		opts.TimeSignature = s.TimeSignature
+	}
+	b, err := newBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range s.MergeTies().Events {
+		if e.Start.Compare(gohar.Duration{}) < 0 || e.Duration.Compare(gohar.Duration{}) < 0 {
+			return nil, fmt.Errorf("%w: event at %s lasting %s", gohar.ErrInvalidSequence, e.Start, e.Duration)
+		}
+		if err := b.add(b.ticks(e.Start), b.ticks(e.Duration), e.Notes...); err != nil {
+			return nil, err
+		}
+	}
+	return b.file(), nil
+}
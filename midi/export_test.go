@@ -0,0 +1,136 @@
+package midi
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestFromNotesInvalidOptions(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Options Options
+		Want    error
+	}{
+		{"channel", Options{Channel: 16}, ErrInvalidOptions},
+		{"velocity", Options{Velocity: 128}, ErrInvalidOptions},
+		{"negative tempo", Options{Tempo: -60}, ErrInvalidOptions},
+		{"slow tempo", Options{Tempo: 1}, ErrInvalidOptions},
+		{"infinite tempo", Options{Tempo: math.Inf(1)}, ErrInvalidOptions},
+		{"NaN tempo", Options{Tempo: math.NaN()}, ErrInvalidOptions},
+		{"time signature", Options{TimeSignature: gohar.TimeSignature{Beats: 3, BeatType: 6}}, gohar.ErrInvalidTimeSignature},
+		{"long bars", Options{TimeSignature: gohar.TimeSignature{Beats: 256, BeatType: 4}}, gohar.ErrInvalidTimeSignature},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := FromNotes([]gohar.Note{gohar.NoteC}, tc.Options)
+			Expect(t, IsError(tc.Want, err))
+		})
+	}
+
+	_, err := FromNotes([]gohar.Note{gohar.NoteC}, Options{Channel: 15, Velocity: 127, Tempo: 4})
+	Expect(t, NoError(err))
+}
+
+// noteStrings returns the notes as "note@start:duration".
+func noteStrings(notes []NoteEvent) []string {
+	s := make([]string, len(notes))
+	for i, n := range notes {
+		s[i] = fmt.Sprintf("%s@%d:%d", n.Note, n.Start, n.Duration)
+	}
+	return s
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	progression, err := gohar.ParseProgression("| Dm7 G7 | C |", 2)
+	Require(t, NoError(err))
+	key := gohar.NewMajorKey(gohar.PitchClassD)
+	var sequence gohar.Sequence
+	sequence.Append(gohar.DurationQuarter.Dotted(1), gohar.NoteF.Sharp())
+	sequence.Append(gohar.DurationEighth)
+	sequence.Append(gohar.DurationHalf, gohar.NoteD, gohar.NoteA)
+	sequence.Events[0].Tied = true
+	testCases := []struct {
+		Name  string
+		Build func(Options) (*File, error)
+		Want  []string
+	}{
+		{
+			"notes",
+			func(opts Options) (*File, error) {
+				return FromNotes([]gohar.Note{gohar.NoteE, gohar.NoteC.Sharp()}, opts)
+			},
+			[]string{"E0@0:480", "C♯0@480:480"},
+		},
+		{
+			"scale",
+			func(opts Options) (*File, error) {
+				return FromScale(gohar.NoteD, gohar.ScalePatternMajor, opts)
+			},
+			[]string{
+				"D0@0:480", "E0@480:480", "F♯0@960:480", "G0@1440:480",
+				"A0@1920:480", "B0@2400:480", "C♯1@2880:480", "D1@3360:480",
+			},
+		},
+		{
+			"chords",
+			func(opts Options) (*File, error) {
+				chords := []gohar.Chord{chordOf(t, "Am"), chordOf(t, "E7/G#")}
+				return FromChords(chords, 0, opts)
+			},
+			[]string{
+				"A0@0:1920", "C1@0:1920", "E1@0:1920",
+				"G♯-1@1920:1920", "E0@1920:1920", "B0@1920:1920", "D1@1920:1920",
+			},
+		},
+		{
+			"progression",
+			func(opts Options) (*File, error) {
+				return FromProgression(progression, 0, opts)
+			},
+			[]string{
+				"D0@0:480", "F0@0:480", "A0@0:480", "C1@0:480",
+				"G0@480:480", "B0@480:480", "D1@480:480", "F1@480:480",
+				"C0@960:960", "E0@960:960", "G0@960:960",
+			},
+		},
+		{
+			"sequence",
+			func(opts Options) (*File, error) {
+				opts.Key = &key
+				return FromSequence(sequence, opts)
+			},
+			[]string{"F♯0@0:720", "D0@960:960", "A0@960:960"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			for _, format := range []Format{SingleTrack, MultiTrack} {
+				f, err := tc.Build(Options{Format: format})
+				Require(t, NoError(err))
+				var buf bytes.Buffer
+				_, err = f.WriteTo(&buf)
+				Require(t, NoError(err))
+				read, err := Read(&buf)
+				Require(t, NoError(err))
+				Expect(t,
+					Equal(format, read.Format),
+					Equal(tc.Want, noteStrings(read.Notes())),
+				)
+			}
+		})
+	}
+}
+
+func chordOf(t *testing.T, symbol string) gohar.Chord {
+	t.Helper()
+	c, err := gohar.ParseChord(symbol)
+	Require(t, NoError(err))
+	return c
+}
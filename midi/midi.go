@@ -0,0 +1,238 @@
+// Package midi reads and writes Standard MIDI Files (SMF), and analyzes their
+// harmony.
+package midi
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+var (
+	ErrInvalidFormat  = errors.New("invalid MIDI file format")
+	ErrInvalidOptions = errors.New("invalid MIDI options")
+)
+
+// Format is the format of a MIDI file.
+type Format uint16
+
+const (
+	// SingleTrack files (format 0) have a single track.
+	SingleTrack Format = 0
+	// MultiTrack files (format 1) have several tracks that are played
+	// simultaneously. The first track conventionally holds the tempo map.
+	MultiTrack Format = 1
+)
+
+// DefaultTicksPerQuarter is the default resolution of MIDI files.
+const DefaultTicksPerQuarter = 480
+
+// A File is a Standard MIDI File.
+type File struct {
+	Format Format
+	// TicksPerQuarter is the number of ticks in a quarter note.
+	TicksPerQuarter uint16
+	Tracks          []Track
+}
+
+// A Track is a sequence of events.
+type Track struct {
+	Events []Event
+}
+
+// An Event is a message that occurs at a given time.
+type Event struct {
+	// Tick is the time of the event, in ticks from the start of the track.
+	Tick uint32
+	// Message is the content of the event.
+	Message Message
+}
+
+// A Message holds the raw bytes of a channel message (e.g. 0x90 key velocity
+// for a note on), or of a meta event: 0xFF, its type, then its data (without
+// its length, that is added when writing).
+type Message []byte
+
+// Meta event types.
+const (
+	MetaTrackName     = 0x03
+	MetaEndOfTrack    = 0x2F
+	MetaTempo         = 0x51
+	MetaTimeSignature = 0x58
+	MetaKeySignature  = 0x59
+)
+
+// NoteOn returns a note on message.
+func NoteOn(channel, key, velocity uint8) Message {
+	return Message{0x90 | channel&0x0F, key & 0x7F, velocity & 0x7F}
+}
+
+// NoteOff returns a note off message.
+func NoteOff(channel, key uint8) Message {
+	return Message{0x80 | channel&0x0F, key & 0x7F, 0}
+}
+
+// IsMeta returns true if the message is a meta event.
+func (m Message) IsMeta() bool {
+	return len(m) >= 2 && m[0] == 0xFF
+}
+
+func meta(kind byte, data ...byte) Message {
+	return append(Message{0xFF, kind}, data...)
+}
+
+// TrackName returns a meta event that names the track.
+func TrackName(name string) Message {
+	return meta(MetaTrackName, []byte(name)...)
+}
+
+// Tempos that can be set with a tempo meta event, in quarter notes per minute:
+// a quarter note lasts between 1 and 2²⁴-1 microseconds.
+const (
+	MinTempo = 60_000_000.0 / 0xFFFFFF
+	MaxTempo = 60_000_000.0
+)
+
+// Tempo returns a meta event that sets the tempo, in quarter notes per minute.
+// The tempo should be between MinTempo and MaxTempo.
+func Tempo(bpm float64) Message {
+	us := uint32(math.Round(60_000_000 / bpm))
+	return meta(MetaTempo, byte(us>>16), byte(us>>8), byte(us))
+}
+
+// TimeSignature returns a meta event that sets the time signature.
+//
+// gohar.ErrInvalidTimeSignature is returned if the time signature is invalid
+// (see [gohar.NewTimeSignature]), or has more than 255 beats.
+func TimeSignature(ts gohar.TimeSignature) (Message, error) {
+	if _, err := gohar.NewTimeSignature(ts.Beats, ts.BeatType); err != nil {
+		return nil, err
+	}
+	if ts.Beats > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: %s has too many beats", gohar.ErrInvalidTimeSignature, ts)
+	}
+	// 24 MIDI clocks per metronome click, 8 32nd notes per quarter
+	return meta(MetaTimeSignature, uint8(ts.Beats), uint8(bits.TrailingZeros(uint(ts.BeatType))), 24, 8), nil
+}
+
+// KeySignature returns a meta event that sets the key signature. Theoretical
+// keys (see [gohar.Key.IsTheoretical]) are written as their enharmonic key.
+func KeySignature(key gohar.Key) Message {
+	if key.IsTheoretical() {
+		key = key.Enharmonic()
+	}
+	var minor byte
+	if key.IsMinor() {
+		minor = 1
+	}
+	return meta(MetaKeySignature, byte(int8(key.Fifths())), minor)
+}
+
+// Add adds a message to the track at given tick.
+func (t *Track) Add(tick uint32, m Message) {
+	t.Events = append(t.Events, Event{tick, m})
+}
+
+// AddNotes adds notes that start and stop together (e.g. a chord) to the track.
+//
+// gohar.ErrInvalidMIDINote is returned if a note is out of the MIDI range.
+func (t *Track) AddNotes(start, duration uint32, channel, velocity uint8, notes ...gohar.Note) error {
+	for _, n := range notes {
+		key, err := n.Pitch().MIDI()
+		if err != nil {
+			return err
+		}
+		t.Add(start, NoteOn(channel, key, velocity))
+		t.Add(start+duration, NoteOff(channel, key))
+	}
+	return nil
+}
+
+// WriteTo writes the file in the Standard MIDI File format.
+// The events of each track are sorted by tick, and an end of track meta event
+// is added at the end.
+//
+// ErrInvalidFormat is returned if a single track file doesn't have exactly
+// one track.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	if f.Format == SingleTrack && len(f.Tracks) != 1 {
+		return 0, fmt.Errorf("%w: single track file with %d tracks", ErrInvalidFormat, len(f.Tracks))
+	}
+	if f.Format > MultiTrack {
+		return 0, fmt.Errorf("%w: format %d", ErrInvalidFormat, f.Format)
+	}
+	division := f.TicksPerQuarter
+	if division == 0 {
+		division = DefaultTicksPerQuarter
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("MThd")
+	for _, v := range []any{uint32(6), uint16(f.Format), uint16(len(f.Tracks)), division} {
+		_ = binary.Write(&buf, binary.BigEndian, v)
+	}
+	for _, t := range f.Tracks {
+		data := t.encode()
+		buf.WriteString("MTrk")
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+		buf.Write(data)
+	}
+	return buf.WriteTo(w)
+}
+
+// encode returns the content of the track chunk.
+func (t Track) encode() []byte {
+	events := slices.DeleteFunc(slices.Clone(t.Events), func(e Event) bool {
+		return isEndOfTrack(e.Message)
+	})
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return cmp.Compare(a.Tick, b.Tick)
+	})
+	var end uint32
+	if len(t.Events) > 0 {
+		end = slices.MaxFunc(t.Events, func(a, b Event) int { return cmp.Compare(a.Tick, b.Tick) }).Tick
+	}
+	events = append(events, Event{end, meta(MetaEndOfTrack)})
+
+	var (
+		buf  []byte
+		tick uint32
+	)
+	for _, e := range events {
+		buf = appendVarLen(buf, e.Tick-tick)
+		tick = e.Tick
+		if e.Message.IsMeta() {
+			buf = append(buf, e.Message[:2]...)
+			buf = appendVarLen(buf, uint32(len(e.Message)-2))
+			buf = append(buf, e.Message[2:]...)
+		} else {
+			buf = append(buf, e.Message...)
+		}
+	}
+	return buf
+}
+
+func isEndOfTrack(m Message) bool {
+	return m.IsMeta() && m[1] == MetaEndOfTrack
+}
+
+// appendVarLen appends a variable-length quantity: 7 bits per byte, most
+// significant bytes first, with the high bit set on all bytes but the last.
+func appendVarLen(buf []byte, v uint32) []byte {
+	var tmp [5]byte
+	i := len(tmp) - 1
+	tmp[i] = byte(v & 0x7F)
+	for v >>= 7; v > 0; v >>= 7 {
+		i--
+		tmp[i] = byte(v&0x7F) | 0x80
+	}
+	return append(buf, tmp[i:]...)
+}
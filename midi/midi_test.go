@@ -0,0 +1,123 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestMessages(t *testing.T) {
+	timeSignature := func(ts gohar.TimeSignature) Message {
+		m, err := TimeSignature(ts)
+		Require(t, NoError(err))
+		return m
+	}
+	testCases := []struct {
+		Name    string
+		Message Message
+		Want    string
+	}{
+		{"note on", NoteOn(1, 60, 100), "913c64"},
+		{"note off", NoteOff(9, 36), "892400"},
+		{"track name", TrackName("Bass"), "ff0342617373"},
+		{"tempo", Tempo(60), "ff510f4240"},
+		{"fast tempo", Tempo(120), "ff5107a120"},
+		{"time signature", timeSignature(gohar.TimeSignatureWaltz), "ff5803021808"},
+		{"compound time signature", timeSignature(gohar.TimeSignature{Beats: 6, BeatType: 8}), "ff5806031808"},
+		{"key signature", KeySignature(gohar.NewMajorKey(gohar.PitchClassB.Flat())), "ff59fe00"},
+		{"minor key signature", KeySignature(gohar.NewMinorKey(gohar.PitchClassE)), "ff590101"},
+		{"theoretical key signature", KeySignature(gohar.NewMajorKey(gohar.PitchClassG.Sharp())), "ff59fc00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			Expect(t, Equal(tc.Want, hex.EncodeToString(tc.Message)))
+		})
+	}
+}
+
+func TestFileWriteTo(t *testing.T) {
+	c := gohar.Chord{Root: gohar.PitchClassC, Pattern: gohar.ChordPatternMajor}
+	key := gohar.NewMinorKey(gohar.PitchClassE)
+	testCases := []struct {
+		Name  string
+		Build func() (*File, error)
+		Want  string
+	}{
+		{
+			"single track",
+			func() (*File, error) {
+				return FromNotes([]gohar.Note{gohar.NoteC, gohar.NoteE}, Options{TicksPerQuarter: 96})
+			},
+			"4d546864000000060000000100604d54726b00000023" +
+				"00ff510307a120" + "00ff580404021808" +
+				"00903c64" + "60803c00" + "00904064" + "60804000" + "00ff2f00",
+		},
+		{
+			"options",
+			func() (*File, error) {
+				return FromNotes([]gohar.Note{gohar.NoteC, gohar.NoteE}, Options{
+					TicksPerQuarter: 96,
+					Tempo:           90,
+					TimeSignature:   gohar.TimeSignatureWaltz,
+					Key:             &key,
+					Name:            "Lead",
+					Channel:         2,
+					Velocity:        64,
+				})
+			},
+			"4d546864000000060000000100604d54726b00000031" +
+				"00ff51030a2c2b" + "00ff580403021808" + "00ff59020101" + "00ff03044c656164" +
+				"00923c40" + "60823c00" + "00924040" + "60824000" + "00ff2f00",
+		},
+		{
+			"multitrack",
+			func() (*File, error) {
+				return FromChords([]gohar.Chord{c}, 0, Options{
+					Format:          MultiTrack,
+					TicksPerQuarter: 96,
+					TimeSignature:   gohar.TimeSignature{Beats: 2, BeatType: 4},
+				})
+			},
+			"4d546864000000060001000200604d54726b00000013" +
+				"00ff510307a120" + "00ff580402021808" + "00ff2f00" +
+				"4d54726b0000001d" +
+				"00903c64" + "00904064" + "00904364" +
+				"8140803c00" + "00804000" + "00804300" + "00ff2f00",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			f, err := tc.Build()
+			Require(t, NoError(err))
+			var buf bytes.Buffer
+			_, err = f.WriteTo(&buf)
+			Expect(t,
+				NoError(err),
+				Equal(tc.Want, hex.EncodeToString(buf.Bytes())),
+			)
+		})
+	}
+}
+
+func TestFileWriteToInvalidFormat(t *testing.T) {
+	testCases := []struct {
+		Name string
+		File File
+	}{
+		{"single track without track", File{Format: SingleTrack}},
+		{"single track with two tracks", File{Format: SingleTrack, Tracks: make([]Track, 2)}},
+		{"unknown format", File{Format: 2, Tracks: make([]Track, 1)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := tc.File.WriteTo(&bytes.Buffer{})
+			Expect(t, IsError(ErrInvalidFormat, err))
+		})
+	}
+}
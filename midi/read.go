@@ -0,0 +1,153 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrInvalidFile = errors.New("invalid MIDI file")
+
+// Read reads a Standard MIDI File. Events are stored with their absolute tick
+// within their track. System exclusive events and unknown chunks are skipped.
+//
+// ErrInvalidFile is returned if the file is malformed, or if its time division
+// is in SMPTE frames (that isn't supported).
+func Read(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	id, header, data, err := readChunk(data)
+	if err != nil {
+		return nil, err
+	}
+	if id != "MThd" || len(header) < 6 {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidFile)
+	}
+	f := &File{
+		Format:          Format(binary.BigEndian.Uint16(header[0:])),
+		TicksPerQuarter: binary.BigEndian.Uint16(header[4:]),
+	}
+	if f.TicksPerQuarter&0x8000 != 0 {
+		return nil, fmt.Errorf("%w: SMPTE time division isn't supported", ErrInvalidFile)
+	}
+	tracks := int(binary.BigEndian.Uint16(header[2:]))
+	for len(data) > 0 && len(f.Tracks) < tracks {
+		var chunk []byte
+		if id, chunk, data, err = readChunk(data); err != nil {
+			return nil, err
+		}
+		if id != "MTrk" {
+			continue
+		}
+		track, err := readTrack(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("%w (track %d)", err, len(f.Tracks))
+		}
+		f.Tracks = append(f.Tracks, track)
+	}
+	return f, nil
+}
+
+// readChunk returns the type and the content of the first chunk of data,
+// along with the rest of the data.
+func readChunk(data []byte) (string, []byte, []byte, error) {
+	if len(data) < 8 {
+		return "", nil, nil, fmt.Errorf("%w: truncated chunk", ErrInvalidFile)
+	}
+	id, size := string(data[:4]), binary.BigEndian.Uint32(data[4:8])
+	data = data[8:]
+	if uint32(len(data)) < size {
+		return "", nil, nil, fmt.Errorf("%w: truncated %q chunk", ErrInvalidFile, id)
+	}
+	return id, data[:size], data[size:], nil
+}
+
+func readTrack(data []byte) (Track, error) {
+	var (
+		t       Track
+		r       = bytes.NewReader(data)
+		tick    uint32
+		running byte
+	)
+	for r.Len() > 0 {
+		delta, err := readVarLen(r)
+		if err != nil {
+			return Track{}, err
+		}
+		tick += delta
+		status, err := r.ReadByte()
+		if err != nil {
+			return Track{}, fmt.Errorf("%w: truncated event", ErrInvalidFile)
+		}
+		switch {
+		case status == 0xFF:
+			running = 0
+			kind, err := r.ReadByte()
+			if err != nil {
+				return Track{}, fmt.Errorf("%w: truncated meta event", ErrInvalidFile)
+			}
+			payload, err := readData(r)
+			if err != nil {
+				return Track{}, err
+			}
+			t.Add(tick, meta(kind, payload...))
+		case status == 0xF0 || status == 0xF7:
+			running = 0
+			if _, err := readData(r); err != nil {
+				return Track{}, err
+			}
+		default:
+			if status < 0x80 {
+				if running == 0 {
+					return Track{}, fmt.Errorf("%w: data byte without status", ErrInvalidFile)
+				}
+				_ = r.UnreadByte()
+				status = running
+			}
+			running = status
+			m := Message{status, 0, 0}
+			if kind := status & 0xF0; kind == 0xC0 || kind == 0xD0 {
+				m = m[:2]
+			}
+			if _, err := io.ReadFull(r, m[1:]); err != nil {
+				return Track{}, fmt.Errorf("%w: truncated channel message", ErrInvalidFile)
+			}
+			t.Add(tick, m)
+		}
+	}
+	return t, nil
+}
+
+// readData reads data prefixed by its variable-length size.
+func readData(r *bytes.Reader) ([]byte, error) {
+	size, err := readVarLen(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > uint32(r.Len()) {
+		return nil, fmt.Errorf("%w: truncated event data", ErrInvalidFile)
+	}
+	data := make([]byte, size)
+	_, _ = r.Read(data)
+	return data, nil
+}
+
+// readVarLen reads a variable-length quantity (see appendVarLen).
+func readVarLen(r *bytes.Reader) (uint32, error) {
+	var v uint32
+	for range 4 {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("%w: truncated variable-length quantity", ErrInvalidFile)
+		}
+		v = v<<7 | uint32(b&0x7F)
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: variable-length quantity is too long", ErrInvalidFile)
+}
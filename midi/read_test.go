@@ -0,0 +1,145 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+// header is the header of a single track file with 96 ticks per quarter.
+const header = "4d54686400000006000000010060"
+
+// track returns a track chunk holding given events.
+func track(events string) string {
+	return fmt.Sprintf("4d54726b%08x", len(events)/2) + events
+}
+
+func readHex(t *testing.T, data string) (*File, error) {
+	t.Helper()
+	b, err := hex.DecodeString(data)
+	Require(t, NoError(err))
+	return Read(bytes.NewReader(b))
+}
+
+func TestReadInvalidFile(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Data string
+	}{
+		{"empty", ""},
+		{"missing header", "4d54726b00000000"},
+		{"short header", "4d5468640000000400000001"},
+		{"SMPTE division", "4d5468640000000600000001e728"},
+		{"truncated chunk", header + "4d54726b000000100090"},
+		{"truncated event", header + "4d54726b0000000100"},
+		{"truncated meta event", header + "4d54726b0000000200ff"},
+		{"truncated meta data", header + "4d54726b0000000400ff0305"},
+		{"truncated channel message", header + "4d54726b00000003009000"},
+		{"data byte without status", header + "4d54726b00000004003c6400"},
+		{"truncated length", header + "4d54726b0000000180"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := readHex(t, tc.Data)
+			Expect(t, IsError(ErrInvalidFile, err))
+		})
+	}
+}
+
+func TestReadNotes(t *testing.T) {
+	f, err := readHex(t, header+track(
+		"00ff5902fd01"+ // C minor
+			"00ff580403021808"+ // 3/4
+			"00c005"+ // program change
+			"00903f50"+ // E♭ on
+			"304050"+ // E on, with running status
+			"303f00"+ // E♭ off, as a null velocity
+			"00804000"+ // E off
+			"00f00105"+ // system exclusive
+			"00ff2f00",
+	))
+	Require(t, NoError(err))
+	Expect(t,
+		Equal(SingleTrack, f.Format),
+		Equal(uint16(96), f.TicksPerQuarter),
+		Equal(gohar.TimeSignatureWaltz, f.TimeSignature()),
+	)
+	key, ok := f.Key()
+	Expect(t,
+		IsTrue(ok),
+		Equal(gohar.NewMinorKey(gohar.PitchClassC), key),
+		Equal([]string{"E♭0@0:96", "E0@48:48"}, noteStrings(f.Notes())),
+	)
+}
+
+func TestReadDefaults(t *testing.T) {
+	f, err := readHex(t, header+track("00ff580400021808"+"00ff2f00"))
+	Require(t, NoError(err))
+	_, ok := f.Key()
+	Expect(t,
+		IsTrue(!ok),
+		Equal(gohar.TimeSignatureCommon, f.TimeSignature()),
+		IsEmptySlice(f.Notes()),
+		IsEmptySlice(f.Analyze(ByBar)),
+	)
+}
+
+func TestFileAnalyze(t *testing.T) {
+	progression, err := gohar.ParseProgression("| Dm7 G7 | Cmaj7 |", 2)
+	Require(t, NoError(err))
+	f, err := FromProgression(progression, 0, Options{})
+	Require(t, NoError(err))
+
+	testCases := []struct {
+		Name   string
+		By     Segmentation
+		Chords []string
+		Starts []uint32
+	}{
+		{"by beat", ByBeat, []string{"Dm7", "G7", "Cmaj7", "Cmaj7"}, []uint32{0, 480, 960, 1440}},
+		{"by bar", ByBar, []string{"Dm7 add11 add13", "Cmaj7"}, []uint32{0, 960}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var (
+				chords []string
+				starts []uint32
+			)
+			for _, s := range f.Analyze(tc.By) {
+				chords = append(chords, s.Chord.String())
+				starts = append(starts, s.Start)
+			}
+			Expect(t, Equal(tc.Chords, chords), Equal(tc.Starts, starts))
+		})
+	}
+}
+
+func TestFileSequence(t *testing.T) {
+	s := gohar.Sequence{TimeSignature: gohar.TimeSignatureWaltz}
+	s.Append(gohar.DurationQuarter.Dotted(1), gohar.NoteF.Sharp(), gohar.NoteA)
+	s.Append(gohar.DurationEighth)
+	s.Append(gohar.DurationHalf, gohar.NoteD.Octave(-1))
+	// a held note starting with a shorter one
+	s.Events = append(s.Events, gohar.Event{Notes: []gohar.Note{gohar.NoteB}, Start: gohar.DurationHalf, Duration: gohar.DurationQuarter})
+	f, err := FromSequence(s, Options{})
+	Require(t, NoError(err))
+	var buf bytes.Buffer
+	_, err = f.WriteTo(&buf)
+	Require(t, NoError(err))
+	read, err := Read(&buf)
+	Require(t, NoError(err))
+
+	got := read.Sequence()
+	Expect(t, Equal(gohar.TimeSignatureWaltz, got.TimeSignature))
+	var events []string
+	for _, e := range got.Events {
+		events = append(events, fmt.Sprintf("%s+%s %v", e.Start, e.Duration, e.Notes))
+	}
+	Expect(t, Equal([]string{"0+3/8 [F♯0 A0]", "1/2+1/2 [D-1]", "1/2+1/4 [B0]"}, events))
+}
@@ -0,0 +1,117 @@
+package musicxml
+
+import "encoding/xml"
+
+// MusicXML elements, in the order required by the schema.
+
+type scorePartwise struct {
+	XMLName  xml.Name `xml:"score-partwise"`
+	Version  string   `xml:"version,attr"`
+	Work     *work    `xml:"work"`
+	PartList partList `xml:"part-list"`
+	Part     part     `xml:"part"`
+}
+
+type work struct {
+	Title string `xml:"work-title"`
+}
+
+type partList struct {
+	ScorePart scorePart `xml:"score-part"`
+}
+
+type scorePart struct {
+	ID       string `xml:"id,attr"`
+	PartName string `xml:"part-name"`
+}
+
+type part struct {
+	ID       string    `xml:"id,attr"`
+	Measures []measure `xml:"measure"`
+}
+
+type measure struct {
+	Number     int         `xml:"number,attr"`
+	Attributes *attributes `xml:"attributes"`
+	// Music holds harmony and note elements.
+	Music []any
+}
+
+type attributes struct {
+	Divisions int            `xml:"divisions"`
+	Key       *key           `xml:"key"`
+	Time      *timeSignature `xml:"time"`
+	Clef      *clef          `xml:"clef"`
+}
+
+type key struct {
+	Fifths int    `xml:"fifths"`
+	Mode   string `xml:"mode,omitempty"`
+}
+
+type timeSignature struct {
+	Beats    int `xml:"beats"`
+	BeatType int `xml:"beat-type"`
+}
+
+type clef struct {
+	Sign string `xml:"sign"`
+	Line int    `xml:"line"`
+}
+
+type harmony struct {
+	XMLName xml.Name        `xml:"harmony"`
+	Root    harmonyRoot     `xml:"root"`
+	Kind    harmonyKind     `xml:"kind"`
+	Bass    *harmonyBass    `xml:"bass"`
+	Degrees []harmonyDegree `xml:"degree"`
+}
+
+type harmonyRoot struct {
+	Step  string `xml:"root-step"`
+	Alter int    `xml:"root-alter,omitempty"`
+}
+
+type harmonyKind struct {
+	Value string `xml:",chardata"`
+	Text  string `xml:"text,attr,omitempty"`
+}
+
+type harmonyBass struct {
+	Step  string `xml:"bass-step"`
+	Alter int    `xml:"bass-alter,omitempty"`
+}
+
+type harmonyDegree struct {
+	Value int    `xml:"degree-value"`
+	Alter int    `xml:"degree-alter"`
+	Type  string `xml:"degree-type"`
+}
+
+type empty struct{}
+
+type note struct {
+	XMLName   xml.Name   `xml:"note"`
+	Chord     *empty     `xml:"chord"`
+	Pitch     *pitch     `xml:"pitch"`
+	Rest      *empty     `xml:"rest"`
+	Duration  int        `xml:"duration"`
+	Ties      []tie      `xml:"tie"`
+	Type      string     `xml:"type,omitempty"`
+	Dots      []empty    `xml:"dot"`
+	Notations *notations `xml:"notations"`
+}
+
+type pitch struct {
+	Step   string `xml:"step"`
+	Alter  int    `xml:"alter,omitempty"`
+	Octave int    `xml:"octave"`
+}
+
+type tie struct {
+	Type string `xml:"type,attr"`
+}
+
+type notations struct {
+	Tied []tie `xml:"tied"`
+}
@@ -0,0 +1,373 @@
+// Package musicxml reads and writes MusicXML documents. It writes MusicXML 4.0
+// partwise documents, and reads partwise and timewise documents, either
+// uncompressed or compressed (.mxl).
+//
+// See https://www.w3.org/2021/06/musicxml40/ for the specification.
+package musicxml
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+// A Score is a single-part score.
+type Score struct {
+	// Title is the title of the work, if any.
+	Title string
+	// PartName is the name of the part ("Music" if empty).
+	PartName string
+	// Key is the key signature, if any.
+	Key *gohar.Key
+	// Music is written as a single voice (see [gohar.Sequence.Voice]), with
+	// the chord symbols of its events above the notes. Its time signature is
+	// 4/4 if zero.
+	Music gohar.Sequence
+}
+
+const header = xml.Header + `<!DOCTYPE score-partwise PUBLIC "-//Recordare//DTD MusicXML 4.0 Partwise//EN" "http://www.musicxml.org/dtds/partwise.dtd">` + "\n"
+
+// WriteTo writes the score as a MusicXML document.
+//
+// gohar.ErrInvalidTimeSignature is returned if the time signature is invalid,
+// and gohar.ErrInvalidSequence if the music isn't a single voice, or has
+// durations that can't be written as notes, such as those of tuplets.
+func (s Score) WriteTo(w io.Writer) (int64, error) {
+	doc, err := s.document()
+	if err != nil {
+		return 0, err
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.WriteString(w, header+string(out)+"\n")
+	return int64(n), err
+}
+
+// String returns the score as a MusicXML document, or an empty string if the
+// score is invalid.
+func (s Score) String() string {
+	var sb strings.Builder
+	if _, err := s.WriteTo(&sb); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+func (s Score) document() (scorePartwise, error) {
+	ts := cmp.Or(s.Music.TimeSignature, gohar.DefaultTimeSignature)
+	if _, err := gohar.NewTimeSignature(ts.Beats, ts.BeatType); err != nil {
+		return scorePartwise{}, err
+	}
+	voice, err := s.Music.Voice()
+	if err != nil {
+		return scorePartwise{}, err
+	}
+	// divisions per quarter note, so that every note is a whole number of
+	// divisions
+	divisions := 1
+	for _, e := range voice.Events {
+		for _, v := range e.Duration.NoteValues() {
+			if _, _, ok := noteType(v); !ok && !v.IsZero() {
+				return scorePartwise{}, fmt.Errorf("%w: duration %s at %s can't be written", gohar.ErrInvalidSequence, v, e.Start)
+			}
+			divisions = max(divisions, v.Den()/4)
+		}
+	}
+
+	partName := s.PartName
+	if partName == "" {
+		partName = "Music"
+	}
+	doc := scorePartwise{
+		Version:  "4.0",
+		PartList: partList{ScorePart: scorePart{ID: "P1", PartName: partName}},
+		Part:     part{ID: "P1"},
+	}
+	if s.Title != "" {
+		doc.Work = &work{Title: s.Title}
+	}
+	attrs := &attributes{
+		Divisions: divisions,
+		Time:      &timeSignature{Beats: ts.Beats, BeatType: ts.BeatType},
+		Clef:      clefFor(voice.Events),
+	}
+	if s.Key != nil {
+		attrs.Key = newKey(*s.Key)
+	}
+
+	m := measure{Number: 1, Attributes: attrs}
+	tiedFrom := false
+	for _, e := range voice.Events {
+		if e.Duration.IsZero() {
+			continue
+		}
+		for m.Number < ts.Position(e.Start).Bar {
+			doc.Part.Measures = append(doc.Part.Measures, m)
+			m = measure{Number: m.Number + 1}
+		}
+		if e.Harmony != nil {
+			m.Music = append(m.Music, newHarmony(*e.Harmony))
+		}
+		// durations that have no note value are split into tied notes
+		values := e.Duration.NoteValues()
+		for i, v := range values {
+			tiedTo := i < len(values)-1 || e.Tied
+			m.Music = append(m.Music, newNotes(e.Notes, v, divisions, tiedFrom, tiedTo)...)
+			tiedFrom = tiedTo
+		}
+	}
+	if len(m.Music) > 0 || len(doc.Part.Measures) == 0 {
+		doc.Part.Measures = append(doc.Part.Measures, m)
+	}
+	return doc, nil
+}
+
+// clefFor returns a bass clef if the notes are mostly below middle C, or a
+// treble clef otherwise.
+func clefFor(events []gohar.Event) *clef {
+	var sum, count int
+	for _, e := range events {
+		for _, n := range e.Notes {
+			sum += int(n.Pitch())
+			count++
+		}
+	}
+	if count > 0 && sum/count < 0 {
+		return &clef{Sign: "F", Line: 4}
+	}
+	return &clef{Sign: "G", Line: 2}
+}
+
+// noteTypes are the note values, indexed by the number of notes in a whole
+// note.
+var noteTypes = map[int]string{
+	1: "whole", 2: "half", 4: "quarter", 8: "eighth", 16: "16th", 32: "32nd", 64: "64th", 128: "128th",
+}
+
+// noteType returns the type of the note lasting given duration, and its number
+// of dots.
+func noteType(d gohar.Duration) (string, int, bool) {
+	value, dots, ok := d.Undotted()
+	if !ok || value.Num() != 1 {
+		return "", 0, false
+	}
+	t, ok := noteTypes[value.Den()]
+	return t, dots, ok
+}
+
+// newNotes returns the note elements of an event lasting given note value, or
+// a rest if there are no notes.
+func newNotes(notes []gohar.Note, value gohar.Duration, divisions int, tiedFrom, tiedTo bool) []any {
+	typ, dots, _ := noteType(value)
+	num, den := value.Div(gohar.DurationQuarter)
+	base := note{Duration: num * divisions / den, Type: typ, Dots: make([]empty, dots)}
+	if len(notes) == 0 {
+		base.Rest = &empty{}
+		return []any{base}
+	}
+	var elements []any
+	for i, n := range notes {
+		e := base
+		e.Pitch = newPitch(n)
+		if i > 0 {
+			e.Chord = &empty{}
+		}
+		var tied []tie
+		if tiedFrom {
+			e.Ties = append(e.Ties, tie{Type: "stop"})
+			tied = append(tied, tie{Type: "stop"})
+		}
+		if tiedTo {
+			e.Ties = append(e.Ties, tie{Type: "start"})
+			tied = append(tied, tie{Type: "start"})
+		}
+		if len(tied) > 0 {
+			e.Notations = &notations{Tied: tied}
+		}
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+// newPitch returns the pitch of a note. MusicXML octaves start on C, with
+// middle C in octave 4.
+func newPitch(n gohar.Note) *pitch {
+	return &pitch{
+		Step:   string(n.BaseName()),
+		Alter:  int(n.Alt()),
+		Octave: int(n.BaseOctave()) + 4,
+	}
+}
+
+func newKey(k gohar.Key) *key {
+	if k.IsTheoretical() {
+		k = k.Enharmonic()
+	}
+	return &key{Fifths: k.Fifths(), Mode: k.Mode()}
+}
+
+func newHarmony(c gohar.Chord) harmony {
+	h := harmony{
+		Root: harmonyRoot{Step: string(c.Root.BaseName()), Alter: int(c.Root.Alt())},
+		Kind: harmonyKind{Value: chordKind(c.Pattern), Text: c.Pattern.Name()},
+	}
+	if c.HasBass() {
+		h.Bass = &harmonyBass{Step: string(c.Bass.BaseName()), Alter: int(c.Bass.Alt())}
+	}
+	h.Degrees = harmonyDegrees(c.Pattern, h.Kind.Value)
+	return h
+}
+
+// harmonyDegrees returns the degrees to remove from, then to add to the chord
+// implied by the kind of harmony to get the chord pattern.
+func harmonyDegrees(c gohar.ChordPattern, kind string) []harmonyDegree {
+	suffix, ok := harmonyKinds[kind]
+	if !ok {
+		return nil
+	}
+	implied, err := gohar.ParseChord("C" + suffix)
+	if err != nil {
+		return nil
+	}
+	c, implied.Pattern = c.Unpack(), implied.Pattern.Unpack()
+	var subtracted, added []harmonyDegree
+	for p := gohar.Pitch(0); p < 24; p++ {
+		switch {
+		case implied.Pattern.HasDegree(p) && !c.HasDegree(p):
+			value, alter := degreeOf(p)
+			subtracted = append(subtracted, harmonyDegree{value, alter, "subtract"})
+		case c.HasDegree(p) && !implied.Pattern.HasDegree(p):
+			value, alter := degreeOf(p)
+			added = append(added, harmonyDegree{value, alter, "add"})
+		}
+	}
+	return append(subtracted, added...)
+}
+
+// degreePitches are the pitches of the degrees of a dominant chord, from the
+// root to the seventh.
+var degreePitches = [7]gohar.Pitch{0, 2, 4, 5, 7, 9, 10}
+
+// chordDegrees are the degrees of chords with their alteration relative to a
+// dominant chord (see degreePitches), by order of preference.
+var chordDegrees = [][2]int{
+	{1, 0}, {3, 0}, {5, 0}, {7, 0}, {9, 0}, {11, 0}, {13, 0},
+	{3, -1}, {5, -1}, {5, 1}, {7, 1}, {9, -1}, {9, 1}, {11, 1}, {13, -1},
+	{2, 0}, {4, 0}, {6, 0}, {2, -1}, {4, 1}, {6, -1}, {13, 1},
+}
+
+// degreeOf returns the degree of a pitch in a chord, and its alteration.
+func degreeOf(p gohar.Pitch) (value, alter int) {
+	for _, d := range chordDegrees {
+		natural := degreePitches[(d[0]-1)%7] + gohar.Pitch((d[0]-1)/7)*gohar.PitchDiffOctave
+		if natural+gohar.Pitch(d[1]) == p {
+			return d[0], d[1]
+		}
+	}
+	return 1, 0
+}
+
+// harmonyKinds are the chord symbol suffixes of the kinds of harmony.
+var harmonyKinds = map[string]string{
+	"major":              "",
+	"minor":              "m",
+	"augmented":          "aug",
+	"diminished":         "dim",
+	"dominant":           "7",
+	"major-seventh":      "maj7",
+	"minor-seventh":      "m7",
+	"diminished-seventh": "dim7",
+	"augmented-seventh":  "aug7",
+	"half-diminished":    "m7b5",
+	"major-minor":        "mmaj7",
+	"major-sixth":        "6",
+	"minor-sixth":        "m6",
+	"dominant-ninth":     "9",
+	"major-ninth":        "maj9",
+	"minor-ninth":        "m9",
+	"dominant-11th":      "11",
+	"major-11th":         "maj11",
+	"minor-11th":         "m11",
+	"dominant-13th":      "13",
+	"major-13th":         "maj13",
+	"minor-13th":         "m13",
+	"suspended-second":   "sus2",
+	"suspended-fourth":   "sus4",
+	"power":              "5",
+}
+
+// chordKind returns the MusicXML kind of a chord pattern, after its third,
+// fifth, seventh and highest extension.
+func chordKind(c gohar.ChordPattern) string {
+	c = c.Unpack().FoldFlatThirteenth()
+	has := c.HasDegree
+	var kind string
+	switch {
+	case has(gohar.PitchDiffMajorThird) && has(gohar.PitchDiffAugmentedFifth) && !has(gohar.PitchDiffPerfectFifth):
+		kind = "augmented"
+		if has(gohar.PitchDiffMinorSeventh) {
+			kind = "augmented-seventh"
+		}
+	case has(gohar.PitchDiffMinorThird) && has(gohar.PitchDiffDiminishedFifth) && !has(gohar.PitchDiffPerfectFifth):
+		switch {
+		case has(gohar.PitchDiffDiminishedSeventh):
+			kind = "diminished-seventh"
+		case has(gohar.PitchDiffMinorSeventh):
+			kind = "half-diminished"
+		default:
+			kind = "diminished"
+		}
+	case has(gohar.PitchDiffMajorThird):
+		kind = seventhKind(c, "major", "dominant", "major-seventh", "major-sixth")
+	case has(gohar.PitchDiffMinorThird):
+		kind = seventhKind(c, "minor", "minor-seventh", "major-minor", "minor-sixth")
+	case has(gohar.PitchDiffPerfectFourth):
+		kind = "suspended-fourth"
+	case has(gohar.PitchDiffMajorSecond):
+		kind = "suspended-second"
+	case c == 0b000010000001:
+		kind = "power"
+	default:
+		kind = "other"
+	}
+	return kind
+}
+
+// seventhKind returns the kind of a chord with a third after its seventh or
+// sixth, and its extensions.
+func seventhKind(c gohar.ChordPattern, triad, minorSeventh, majorSeventh, sixth string) string {
+	var base string
+	switch {
+	case c.HasDegree(gohar.PitchDiffMinorSeventh):
+		base = minorSeventh
+	case c.HasDegree(gohar.PitchDiffMajorSeventh):
+		base = majorSeventh
+	case c.HasDegree(gohar.PitchDiffMajorSixth):
+		return sixth
+	default:
+		return triad
+	}
+	// only dominant, major and minor sevenths have extended kinds
+	prefix, ok := map[string]string{
+		"dominant": "dominant", "major-seventh": "major", "minor-seventh": "minor",
+	}[base]
+	if !ok {
+		return base
+	}
+	// altered extensions are written as degrees
+	switch {
+	case c.HasDegree(gohar.PitchDiffMajorThirteenth):
+		return prefix + "-13th"
+	case c.HasDegree(gohar.PitchDiffPerfectEleventh):
+		return prefix + "-11th"
+	case c.HasDegree(gohar.PitchDiffMajorNinth):
+		return prefix + "-ninth"
+	}
+	return base
+}
@@ -0,0 +1,272 @@
+package musicxml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+// measures returns the measures of the score as written, on a single line.
+func measures(t *testing.T, s Score) string {
+	t.Helper()
+	var sb strings.Builder
+	_, err := s.WriteTo(&sb)
+	Require(t, NoError(err))
+	_, body, _ := strings.Cut(sb.String(), `<part id="P1">`)
+	body, _, _ = strings.Cut(body, "</part>")
+	var out strings.Builder
+	for line := range strings.Lines(body) {
+		out.WriteString(strings.TrimSpace(line))
+	}
+	return out.String()
+}
+
+func TestScoreWriteTo(t *testing.T) {
+	var s gohar.Sequence
+	s.Append(gohar.DurationWhole, gohar.NoteC)
+	var sb strings.Builder
+	n, err := Score{Title: "Scale", PartName: "Piano", Music: s}.WriteTo(&sb)
+	Require(t, NoError(err))
+	Expect(t,
+		Equal(int64(sb.Len()), n),
+		Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE score-partwise PUBLIC "-//Recordare//DTD MusicXML 4.0 Partwise//EN" "http://www.musicxml.org/dtds/partwise.dtd">
+<score-partwise version="4.0">
+  <work>
+    <work-title>Scale</work-title>
+  </work>
+  <part-list>
+    <score-part id="P1">
+      <part-name>Piano</part-name>
+    </score-part>
+  </part-list>
+  <part id="P1">
+    <measure number="1">
+      <attributes>
+        <divisions>1</divisions>
+        <time>
+          <beats>4</beats>
+          <beat-type>4</beat-type>
+        </time>
+        <clef>
+          <sign>G</sign>
+          <line>2</line>
+        </clef>
+      </attributes>
+      <note>
+        <pitch>
+          <step>C</step>
+          <octave>4</octave>
+        </pitch>
+        <duration>4</duration>
+        <type>whole</type>
+      </note>
+    </measure>
+  </part>
+</score-partwise>
+`, sb.String()),
+	)
+}
+
+func TestScoreMeasures(t *testing.T) {
+	const (
+		common = `<attributes><divisions>1</divisions><time><beats>4</beats><beat-type>4</beat-type></time>` +
+			`<clef><sign>G</sign><line>2</line></clef></attributes>`
+		rest = `<note><rest></rest><duration>4</duration><type>whole</type></note>`
+	)
+	bb7, err := gohar.ParseChord("Bb7/D")
+	Require(t, NoError(err))
+	cm6, err := gohar.ParseChord("Cm6")
+	Require(t, NoError(err))
+	bMinor := gohar.NewMinorKey(gohar.PitchClassB)
+	gSharpMajor := gohar.NewMajorKey(gohar.PitchClassG.Sharp())
+
+	testCases := []struct {
+		Name  string
+		Score func() Score
+		Want  string
+	}{
+		{
+			"empty",
+			func() Score { return Score{} },
+			`<measure number="1">` + common + `</measure>`,
+		},
+		{
+			"rests, dots and ties",
+			func() Score {
+				s := gohar.Sequence{TimeSignature: gohar.TimeSignatureWaltz}
+				s.Append(gohar.DurationEighth)
+				s.Append(gohar.DurationHalf.Dotted(1), gohar.NoteE.Flat())
+				s.Append(gohar.DurationQuarter.Dotted(1), gohar.NoteA.Octave(-1))
+				return Score{Music: s}
+			},
+			`<measure number="1"><attributes><divisions>2</divisions><time><beats>3</beats><beat-type>4</beat-type></time>` +
+				`<clef><sign>G</sign><line>2</line></clef></attributes>` +
+				`<note><rest></rest><duration>1</duration><type>eighth</type></note>` +
+				`<note><pitch><step>E</step><alter>-1</alter><octave>4</octave></pitch><duration>4</duration>` +
+				`<tie type="start"></tie><type>half</type><notations><tied type="start"></tied></notations></note>` +
+				`<note><pitch><step>E</step><alter>-1</alter><octave>4</octave></pitch><duration>1</duration>` +
+				`<tie type="stop"></tie><tie type="start"></tie><type>eighth</type>` +
+				`<notations><tied type="stop"></tied><tied type="start"></tied></notations></note>` +
+				`</measure><measure number="2">` +
+				`<note><pitch><step>E</step><alter>-1</alter><octave>4</octave></pitch><duration>1</duration>` +
+				`<tie type="stop"></tie><type>eighth</type><notations><tied type="stop"></tied></notations></note>` +
+				`<note><pitch><step>A</step><octave>3</octave></pitch><duration>3</duration><type>quarter</type><dot></dot></note>` +
+				`</measure>`,
+		},
+		{
+			"gap",
+			func() Score {
+				s := gohar.Sequence{Events: []gohar.Event{{
+					Notes:    []gohar.Note{gohar.NoteG},
+					Start:    gohar.DurationWhole,
+					Duration: gohar.DurationWhole,
+				}}}
+				return Score{Music: s}
+			},
+			`<measure number="1">` + common + rest + `</measure>` +
+				`<measure number="2"><note><pitch><step>G</step><octave>4</octave></pitch>` +
+				`<duration>4</duration><type>whole</type></note></measure>`,
+		},
+		{
+			"chords",
+			func() Score {
+				var s gohar.Sequence
+				s.AppendChord(gohar.DurationHalf, bb7, -1)
+				s.AppendChord(gohar.DurationHalf, cm6, 0)
+				return Score{Music: s}
+			},
+			`<measure number="1">` + common +
+				`<harmony><root><root-step>B</root-step><root-alter>-1</root-alter></root><kind text="7">dominant</kind>` +
+				`<bass><bass-step>D</bass-step></bass></harmony>` +
+				`<note><pitch><step>D</step><octave>3</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`<note><chord></chord><pitch><step>B</step><alter>-1</alter><octave>3</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`<note><chord></chord><pitch><step>F</step><octave>4</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`<note><chord></chord><pitch><step>A</step><alter>-1</alter><octave>4</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`<harmony><root><root-step>C</root-step></root><kind text="m6">minor-sixth</kind></harmony>` +
+				`<note><pitch><step>C</step><octave>4</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`<note><chord></chord><pitch><step>E</step><alter>-1</alter><octave>4</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`<note><chord></chord><pitch><step>G</step><octave>4</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`<note><chord></chord><pitch><step>A</step><octave>4</octave></pitch><duration>2</duration><type>half</type></note>` +
+				`</measure>`,
+		},
+		{
+			"key and bass clef",
+			func() Score {
+				var s gohar.Sequence
+				s.Append(gohar.DurationWhole, gohar.NoteB.Octave(-2))
+				return Score{Key: &bMinor, Music: s}
+			},
+			`<measure number="1"><attributes><divisions>1</divisions><key><fifths>2</fifths><mode>minor</mode></key>` +
+				`<time><beats>4</beats><beat-type>4</beat-type></time><clef><sign>F</sign><line>4</line></clef></attributes>` +
+				`<note><pitch><step>B</step><octave>2</octave></pitch><duration>4</duration><type>whole</type></note>` +
+				`</measure>`,
+		},
+		{
+			"theoretical key",
+			func() Score { return Score{Key: &gSharpMajor} },
+			`<measure number="1"><attributes><divisions>1</divisions><key><fifths>-4</fifths><mode>major</mode></key>` +
+				`<time><beats>4</beats><beat-type>4</beat-type></time><clef><sign>G</sign><line>2</line></clef></attributes>` +
+				`</measure>`,
+		},
+		{
+			"tied events",
+			func() Score {
+				s := gohar.Sequence{TimeSignature: gohar.TimeSignature{Beats: 2, BeatType: 4}}
+				s.Append(gohar.DurationQuarter.Add(gohar.DurationSixteenth), gohar.NoteD)
+				s.Events[0].Tied = true
+				s.Append(gohar.DurationEighth.Dotted(1), gohar.NoteD)
+				return Score{Music: s}
+			},
+			`<measure number="1"><attributes><divisions>4</divisions><time><beats>2</beats><beat-type>4</beat-type></time>` +
+				`<clef><sign>G</sign><line>2</line></clef></attributes>` +
+				`<note><pitch><step>D</step><octave>4</octave></pitch><duration>4</duration>` +
+				`<tie type="start"></tie><type>quarter</type><notations><tied type="start"></tied></notations></note>` +
+				`<note><pitch><step>D</step><octave>4</octave></pitch><duration>1</duration>` +
+				`<tie type="stop"></tie><tie type="start"></tie><type>16th</type>` +
+				`<notations><tied type="stop"></tied><tied type="start"></tied></notations></note>` +
+				`<note><pitch><step>D</step><octave>4</octave></pitch><duration>3</duration>` +
+				`<tie type="stop"></tie><type>eighth</type><dot></dot><notations><tied type="stop"></tied></notations></note>` +
+				`</measure>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			Expect(t, Equal(tc.Want, measures(t, tc.Score())))
+		})
+	}
+}
+
+func TestScoreWriteToInvalid(t *testing.T) {
+	overlapping := gohar.Sequence{Events: []gohar.Event{
+		{Notes: []gohar.Note{gohar.NoteC}, Duration: gohar.DurationHalf},
+		{Notes: []gohar.Note{gohar.NoteE}, Duration: gohar.DurationHalf},
+	}}
+	var triplets gohar.Sequence
+	triplets.Append(gohar.DurationQuarter.Tuplet(3, 2), gohar.NoteC)
+
+	testCases := []struct {
+		Name  string
+		Music gohar.Sequence
+		Want  error
+	}{
+		{"time signature", gohar.Sequence{TimeSignature: gohar.TimeSignature{Beats: 3, BeatType: 5}}, gohar.ErrInvalidTimeSignature},
+		{"overlapping events", overlapping, gohar.ErrInvalidSequence},
+		{"tuplets", triplets, gohar.ErrInvalidSequence},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := Score{Music: tc.Music}.WriteTo(&strings.Builder{})
+			Expect(t,
+				IsError(tc.Want, err),
+				Equal("", Score{Music: tc.Music}.String()),
+			)
+		})
+	}
+}
+
+func TestScoreRoundTrip(t *testing.T) {
+	progression, err := gohar.ParseProgression("| Dm7 G7 | C6/E | F#m7b5 B7b9 | Em |", 4)
+	Require(t, NoError(err))
+	key := gohar.NewMinorKey(gohar.PitchClassE)
+	s := progression.Sequence(0, gohar.TimeSignatureCommon)
+	s.Append(gohar.DurationHalf.Dotted(1), gohar.NoteB.Octave(-1))
+
+	var sb strings.Builder
+	_, err = Score{Title: "Turnaround", Key: &key, Music: s}.WriteTo(&sb)
+	Require(t, NoError(err))
+	doc, err := Read(strings.NewReader(sb.String()))
+	Require(t, NoError(err))
+	Require(t,
+		Equal("Turnaround", doc.Title),
+		Equal(1, doc.Divisions),
+		SliceHasLength(1, doc.Parts),
+	)
+	part := doc.Parts[0]
+
+	var notes []NoteEvent
+	for _, e := range s.Events {
+		for _, n := range e.Notes {
+			start, _ := e.Start.Div(gohar.DurationQuarter)
+			duration, _ := e.Duration.Div(gohar.DurationQuarter)
+			measure, _ := e.Start.Count(gohar.DurationWhole)
+			notes = append(notes, NoteEvent{Note: n, Start: start, Duration: duration, Measure: measure + 1, Voice: "1"})
+		}
+	}
+	var harmonies []string
+	for _, h := range part.Harmonies {
+		harmonies = append(harmonies, h.Chord.String())
+	}
+	Expect(t,
+		Equal("Music", part.Name),
+		Equal(gohar.TimeSignatureCommon, part.TimeSignature),
+		Equal(eventStrings(s), eventStrings(doc.Sequence(part))),
+		Equal(notes, part.Notes),
+		Equal([]string{"Dm7", "G7", "C6/E", "F♯m7♭5", "B7♭9", "Em"}, harmonies),
+		Equal([]KeyEvent{{Key: key, Measure: 1}}, part.Keys),
+	)
+}
@@ -0,0 +1,617 @@
+package musicxml
+
+import (
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+var ErrInvalidFile = errors.New("invalid MusicXML file")
+
+// A Document is a score read from a MusicXML file.
+type Document struct {
+	// Title is the title of the work, or of the movement if the work has none.
+	Title string
+	// Divisions is the number of time units per quarter note. Every time of
+	// the document is expressed in these units.
+	Divisions int
+	Parts     []Part
+}
+
+// A Part is a part of a score, such as an instrument.
+type Part struct {
+	ID, Name string
+	// Notes are the notes of the part, sorted by start time. Tied notes are
+	// merged into a single note.
+	Notes []NoteEvent
+	// Harmonies are the chord symbols of the part, sorted by start time.
+	Harmonies []HarmonyEvent
+	// Keys are the key signatures of the part, sorted by start time.
+	Keys []KeyEvent
+	// TimeSignature is the first supported time signature of the part (not
+	// "3+2/8", for instance), or zero if there is none.
+	TimeSignature gohar.TimeSignature
+}
+
+// A NoteEvent is a note played during a span of time.
+type NoteEvent struct {
+	Note gohar.Note
+	// Start and Duration are in divisions of the document.
+	Start, Duration int
+	// Measure is the position of the measure where the note starts, from 1.
+	// It may differ from the number printed on the score.
+	Measure int
+	// Voice is the voice of the note ("1" if unspecified).
+	Voice string
+}
+
+// End returns the time at which the note stops.
+func (n NoteEvent) End() int {
+	return n.Start + n.Duration
+}
+
+// A HarmonyEvent is a chord symbol written on the score.
+type HarmonyEvent struct {
+	Chord   gohar.Chord
+	Start   int
+	Measure int
+}
+
+// A KeyEvent is a change of key signature.
+type KeyEvent struct {
+	Key     gohar.Key
+	Start   int
+	Measure int
+}
+
+// Voices returns the voices of the part, in order of appearance.
+func (p Part) Voices() []string {
+	var voices []string
+	for _, n := range p.Notes {
+		if !slices.Contains(voices, n.Voice) {
+			voices = append(voices, n.Voice)
+		}
+	}
+	return voices
+}
+
+// Voice returns the notes of given voice.
+func (p Part) Voice(voice string) []NoteEvent {
+	var notes []NoteEvent
+	for _, n := range p.Notes {
+		if n.Voice == voice {
+			notes = append(notes, n)
+		}
+	}
+	return notes
+}
+
+// Sequence returns the notes and the chord symbols of a part of the document as
+// a sequence of events, with the time signature of the part. Times are
+// converted from divisions of the document to fractions of a whole note.
+//
+// Notes of the same voice that start and stop together are grouped in a single
+// event. Chord symbols are set on the first event that starts at their time,
+// splitting an event that is held at that time if there is none, or else on a
+// rest that lasts until the next note.
+func (d *Document) Sequence(p Part) gohar.Sequence {
+	whole := 4 * max(d.Divisions, 1)
+	s := gohar.Sequence{TimeSignature: p.TimeSignature}
+	var voices []string // voices of the events
+	for _, n := range p.Notes {
+		start, duration := gohar.NewDuration(n.Start, whole), gohar.NewDuration(n.Duration, whole)
+		i := len(s.Events) - 1
+		for ; i >= 0 && s.Events[i].Start.Equal(start); i-- {
+			if voices[i] == n.Voice && s.Events[i].Duration.Equal(duration) {
+				s.Events[i].Notes = append(s.Events[i].Notes, n.Note)
+				break
+			}
+		}
+		if i < 0 || !s.Events[i].Start.Equal(start) {
+			s.Events = append(s.Events, gohar.Event{Notes: []gohar.Note{n.Note}, Start: start, Duration: duration})
+			voices = append(voices, n.Voice)
+		}
+	}
+	for _, h := range p.Harmonies {
+		setHarmony(&s, gohar.NewDuration(h.Start, whole), h.Chord)
+	}
+	return s
+}
+
+// setHarmony sets a chord symbol at given time of the sequence.
+func setHarmony(s *gohar.Sequence, at gohar.Duration, c gohar.Chord) {
+	i, found := slices.BinarySearchFunc(s.Events, at, func(e gohar.Event, t gohar.Duration) int {
+		return e.Start.Compare(t)
+	})
+	if !found {
+		e := gohar.Event{Start: at}
+		if held := slices.IndexFunc(s.Events[:i], func(e gohar.Event) bool { return e.End().Compare(at) > 0 }); held >= 0 {
+			s.Events[held], e, _ = s.Events[held].Split(at)
+		} else if i < len(s.Events) {
+			e.Duration = s.Events[i].Start.Sub(at)
+		}
+		s.Events = slices.Insert(s.Events, i, e)
+	}
+	s.Events[i].Harmony = &c
+}
+
+// Read reads a MusicXML document, either partwise or timewise, uncompressed or
+// compressed (.mxl).
+//
+// Grace notes, cue notes, rests and unpitched notes are skipped, and so are
+// chord symbols of unknown kind (see [ParseHarmony]). Microtonal alterations
+// are rounded to the nearest semitone.
+//
+// ErrInvalidFile is returned if the file is malformed.
+func Read(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		if data, err = readMXL(data); err != nil {
+			return nil, err
+		}
+	}
+	var score xmlScore
+	if err := xml.Unmarshal(data, &score); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	switch score.XMLName.Local {
+	case "score-partwise":
+	case "score-timewise":
+		score.Parts = score.partwise()
+	default:
+		return nil, fmt.Errorf("%w: unexpected root element %q", ErrInvalidFile, score.XMLName.Local)
+	}
+
+	doc := &Document{Title: cmp.Or(score.Work.Title, score.MovementTitle), Divisions: 1}
+	for _, p := range score.Parts {
+		for _, m := range p.Measures {
+			for _, e := range m.Music {
+				if a, ok := e.(*xmlAttributes); ok && a.Divisions > 0 {
+					doc.Divisions = lcm(doc.Divisions, a.Divisions)
+				}
+			}
+		}
+	}
+	for _, p := range score.Parts {
+		part, err := doc.readPart(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w (part %q)", err, p.ID)
+		}
+		for _, sp := range score.PartList.ScoreParts {
+			if sp.ID == p.ID {
+				part.Name = sp.Name
+			}
+		}
+		doc.Parts = append(doc.Parts, part)
+	}
+	return doc, nil
+}
+
+// readMXL returns the main document of a compressed MusicXML file, after its
+// container, or the first MusicXML document of the archive.
+func readMXL(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	var name string
+	if container, err := readZipFile(archive, "META-INF/container.xml"); err == nil {
+		var c struct {
+			RootFiles []struct {
+				Path string `xml:"full-path,attr"`
+			} `xml:"rootfiles>rootfile"`
+		}
+		if err := xml.Unmarshal(container, &c); err == nil && len(c.RootFiles) > 0 {
+			name = c.RootFiles[0].Path
+		}
+	}
+	if name == "" {
+		for _, f := range archive.File {
+			if ext := path.Ext(f.Name); !strings.HasPrefix(f.Name, "META-INF/") && (ext == ".xml" || ext == ".musicxml") {
+				name = f.Name
+				break
+			}
+		}
+	}
+	return readZipFile(archive, name)
+}
+
+func readZipFile(archive *zip.Reader, name string) ([]byte, error) {
+	f, err := archive.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// readPart reads the measures of a part, and expresses their times in
+// divisions of the document.
+func (d *Document) readPart(p xmlPart) (Part, error) {
+	type tieKey struct {
+		voice string
+		pitch gohar.Pitch
+	}
+	var (
+		part  = Part{ID: p.ID}
+		scale = d.Divisions
+		start int // start of the measure
+		tied  = make(map[tieKey]int)
+	)
+	for i, m := range p.Measures {
+		measure := i + 1
+		pos, end, last := start, start, start
+		for _, e := range m.Music {
+			switch e := e.(type) {
+			case *xmlAttributes:
+				if e.Divisions > 0 {
+					scale = d.Divisions / e.Divisions
+				}
+				if len(e.Keys) > 0 {
+					key, err := e.Keys[0].key()
+					if err != nil {
+						return Part{}, fmt.Errorf("%w (measure %d)", err, measure)
+					}
+					part.Keys = append(part.Keys, KeyEvent{Key: key, Start: pos, Measure: measure})
+				}
+				for _, t := range e.Times {
+					if ts, err := gohar.ParseTimeSignature(t.Beats + "/" + t.BeatType); err == nil && part.TimeSignature.Beats == 0 {
+						part.TimeSignature = ts
+					}
+				}
+			case *xmlBackup:
+				pos -= e.Duration * scale
+			case *xmlForward:
+				pos += e.Duration * scale
+			case *xmlHarmony:
+				if chord, err := ParseHarmony(e.Root.Step, e.Root.Alter, e.Kind.Value, e.Kind.Text); err == nil {
+					if e.Bass != nil && e.Bass.Step != "" {
+						chord.Bass, _ = gohar.NewPitchClassFromChar(strings.ToUpper(e.Bass.Step)[0], round(e.Bass.Alter))
+					}
+					for _, dg := range e.Degrees {
+						if chord.Pattern, err = dg.apply(chord.Pattern); err != nil {
+							return Part{}, fmt.Errorf("%w (measure %d)", err, measure)
+						}
+					}
+					part.Harmonies = append(part.Harmonies, HarmonyEvent{Chord: chord, Start: pos + e.Offset*scale, Measure: measure})
+				}
+			case *xmlNote:
+				if e.Grace != nil {
+					continue
+				}
+				noteStart, duration := pos, e.Duration*scale
+				if e.Chord != nil {
+					noteStart = last
+				} else {
+					pos += duration
+				}
+				last = noteStart
+				end = max(end, pos)
+				if e.Pitch == nil || e.Cue != nil {
+					continue
+				}
+				note, err := e.Pitch.note()
+				if err != nil {
+					return Part{}, fmt.Errorf("%w (measure %d)", err, measure)
+				}
+				voice := cmp.Or(e.Voice, "1")
+				key := tieKey{voice, note.Pitch()}
+				if i, ok := tied[key]; ok && e.hasTie("stop") && part.Notes[i].End() == noteStart {
+					part.Notes[i].Duration += duration
+					if !e.hasTie("start") {
+						delete(tied, key)
+					}
+					continue
+				}
+				if e.hasTie("start") {
+					tied[key] = len(part.Notes)
+				}
+				part.Notes = append(part.Notes, NoteEvent{
+					Note:     note,
+					Start:    noteStart,
+					Duration: duration,
+					Measure:  measure,
+					Voice:    voice,
+				})
+			}
+			end = max(end, pos)
+		}
+		start = end
+	}
+	slices.SortStableFunc(part.Notes, func(a, b NoteEvent) int { return a.Start - b.Start })
+	slices.SortStableFunc(part.Harmonies, func(a, b HarmonyEvent) int { return a.Start - b.Start })
+	return part, nil
+}
+
+// ParseHarmony returns the chord of a MusicXML chord symbol, after its root
+// step and alteration, and its kind. If the kind is unknown (such as "other"),
+// the chord is parsed from the text of the kind, if any.
+//
+// gohar.ErrInvalidChordSymbol is returned if the chord can't be identified.
+func ParseHarmony(step string, alter float64, kind, text string) (gohar.Chord, error) {
+	alt := int(round(alter))
+	symbol := step + strings.Repeat("#", max(0, alt)) + strings.Repeat("b", max(0, -alt))
+	if suffix, ok := harmonyKinds[strings.TrimSpace(kind)]; ok {
+		symbol += suffix
+	} else if text != "" {
+		symbol += text
+	} else {
+		return gohar.Chord{}, fmt.Errorf("%w: unknown kind %q", gohar.ErrInvalidChordSymbol, kind)
+	}
+	return gohar.ParseChord(symbol)
+}
+
+func round(f float64) gohar.Pitch {
+	return gohar.Pitch(math.Round(f))
+}
+
+func lcm(a, b int) int {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
+
+// MusicXML elements, as read. Only the elements and attributes that matter to
+// gohar are decoded.
+
+type xmlScore struct {
+	XMLName xml.Name
+	Work    struct {
+		Title string `xml:"work-title"`
+	} `xml:"work"`
+	MovementTitle string `xml:"movement-title"`
+	PartList      struct {
+		ScoreParts []struct {
+			ID   string `xml:"id,attr"`
+			Name string `xml:"part-name"`
+		} `xml:"score-part"`
+	} `xml:"part-list"`
+	// Parts are the parts of a partwise score.
+	Parts []xmlPart `xml:"part"`
+	// Measures are the measures of a timewise score.
+	Measures []struct {
+		Parts []xmlMeasure `xml:"part"`
+	} `xml:"measure"`
+}
+
+// partwise returns the parts of a timewise score.
+func (s xmlScore) partwise() []xmlPart {
+	var parts []xmlPart
+	for _, m := range s.Measures {
+		for _, mp := range m.Parts {
+			i := slices.IndexFunc(parts, func(p xmlPart) bool { return p.ID == mp.ID })
+			if i < 0 {
+				i = len(parts)
+				parts = append(parts, xmlPart{ID: mp.ID})
+			}
+			parts[i].Measures = append(parts[i].Measures, mp)
+		}
+	}
+	return parts
+}
+
+type xmlPart struct {
+	ID       string       `xml:"id,attr"`
+	Measures []xmlMeasure `xml:"measure"`
+}
+
+// An xmlMeasure is a measure of a partwise score, or a part of a measure of a
+// timewise score.
+type xmlMeasure struct {
+	// ID is the ID of the part, in timewise scores.
+	ID string
+	// Music holds the musical elements of the measure, in order.
+	Music []any
+}
+
+func (m *xmlMeasure) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "id" {
+			m.ID = attr.Value
+		}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		var e any
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "note":
+				e = &xmlNote{}
+			case "backup":
+				e = &xmlBackup{}
+			case "forward":
+				e = &xmlForward{}
+			case "attributes":
+				e = &xmlAttributes{}
+			case "harmony":
+				e = &xmlHarmony{}
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.DecodeElement(e, &tok); err != nil {
+				return err
+			}
+			m.Music = append(m.Music, e)
+		}
+	}
+}
+
+type xmlNote struct {
+	Grace    *struct{} `xml:"grace"`
+	Cue      *struct{} `xml:"cue"`
+	Chord    *struct{} `xml:"chord"`
+	Pitch    *xmlPitch `xml:"pitch"`
+	Duration int       `xml:"duration"`
+	Ties     []xmlTie  `xml:"tie"`
+	Voice    string    `xml:"voice"`
+}
+
+type xmlTie struct {
+	Type string `xml:"type,attr"`
+}
+
+func (n *xmlNote) hasTie(kind string) bool {
+	return slices.ContainsFunc(n.Ties, func(t xmlTie) bool { return t.Type == kind })
+}
+
+type xmlPitch struct {
+	Step   string  `xml:"step"`
+	Alter  float64 `xml:"alter"`
+	Octave int8    `xml:"octave"`
+}
+
+// note returns the note of a pitch. MusicXML octaves start on C, with middle C
+// in octave 4.
+func (p *xmlPitch) note() (gohar.Note, error) {
+	if len(p.Step) != 1 {
+		return gohar.Note{}, fmt.Errorf("%w: invalid step %q", ErrInvalidFile, p.Step)
+	}
+	base, err := gohar.NewPitchClassFromChar(strings.ToUpper(p.Step)[0], 0)
+	if err != nil {
+		return gohar.Note{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	alter := round(p.Alter)
+	pc, err := gohar.NewPitchClassFromChar(base.BaseName(), alter)
+	if err != nil {
+		return gohar.Note{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	return gohar.NoteWithPitch(pc, base.Pitch(p.Octave-4)+alter), nil
+}
+
+type xmlBackup struct {
+	Duration int `xml:"duration"`
+}
+
+type xmlForward struct {
+	Duration int `xml:"duration"`
+}
+
+type xmlAttributes struct {
+	Divisions int       `xml:"divisions"`
+	Keys      []xmlKey  `xml:"key"`
+	Times     []xmlTime `xml:"time"`
+}
+
+type xmlTime struct {
+	Beats    string `xml:"beats"`
+	BeatType string `xml:"beat-type"`
+}
+
+type xmlKey struct {
+	Fifths *int   `xml:"fifths"`
+	Mode   string `xml:"mode"`
+}
+
+// key returns the key of a traditional key signature. Non-traditional key
+// signatures (that alter arbitrary notes) aren't supported.
+func (k xmlKey) key() (gohar.Key, error) {
+	mode := strings.TrimSpace(k.Mode)
+	if mode == "none" {
+		mode = ""
+	}
+	pattern, err := gohar.ParseKeyMode(mode)
+	if k.Fifths == nil || err != nil {
+		return gohar.Key{}, fmt.Errorf("%w: unsupported key signature", ErrInvalidFile)
+	}
+	key, err := gohar.NewKeyFromSignature(*k.Fifths, pattern)
+	if err != nil {
+		return gohar.Key{}, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	return key, nil
+}
+
+type xmlHarmony struct {
+	Root struct {
+		Step  string  `xml:"root-step"`
+		Alter float64 `xml:"root-alter"`
+	} `xml:"root"`
+	Kind struct {
+		Value string `xml:",chardata"`
+		Text  string `xml:"text,attr"`
+	} `xml:"kind"`
+	Bass *struct {
+		Step  string  `xml:"bass-step"`
+		Alter float64 `xml:"bass-alter"`
+	} `xml:"bass"`
+	Degrees []xmlDegree `xml:"degree"`
+	Offset  int         `xml:"offset"`
+}
+
+type xmlDegree struct {
+	Value int     `xml:"degree-value"`
+	Alter float64 `xml:"degree-alter"`
+	Type  string  `xml:"degree-type"`
+}
+
+// apply adds, alters or removes the degree of a chord pattern. Added degrees
+// are relative to a dominant chord, and altered or removed degrees to the
+// degree found in the chord.
+//
+// ErrInvalidFile is returned if the degree is out of the range of a chord
+// pattern (from the root to two octaves above).
+func (d xmlDegree) apply(c gohar.ChordPattern) (gohar.ChordPattern, error) {
+	if d.Value < 1 {
+		return c, nil
+	}
+	natural := degreePitches[(d.Value-1)%7] + gohar.Pitch((d.Value-1)/7)*gohar.PitchDiffOctave
+	alter := round(d.Alter)
+	switch strings.TrimSpace(d.Type) {
+	case "add":
+		if err := d.check(natural + alter); err != nil {
+			return c, err
+		}
+		return c.Add(natural + alter), nil
+	case "alter", "subtract":
+		found := natural + alter
+		for _, p := range []gohar.Pitch{natural, natural - 1, natural + 1} {
+			if p >= 0 && c.HasDegree(p) {
+				found = p
+				break
+			}
+		}
+		if err := d.check(found); err != nil {
+			return c, err
+		}
+		c = c.Omit(found)
+		if d.Type == "alter" {
+			if err := d.check(found + alter); err != nil {
+				return c, err
+			}
+			c = c.Add(found + alter)
+		}
+	}
+	return c, nil
+}
+
+// check returns an error if the pitch of the degree doesn't fit in a chord
+// pattern.
+func (d xmlDegree) check(p gohar.Pitch) error {
+	if p < 0 || p > 23 {
+		return fmt.Errorf("%w: degree %d altered by %v", ErrInvalidFile, d.Value, d.Alter)
+	}
+	return nil
+}
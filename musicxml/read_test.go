@@ -0,0 +1,336 @@
+package musicxml
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+const partwise = `<?xml version="1.0" encoding="UTF-8"?>
+<score-partwise version="4.0">
+  <movement-title>Étude</movement-title>
+  <part-list>
+    <score-part id="P1"><part-name>Piano</part-name></score-part>
+  </part-list>
+  <part id="P1">
+    <measure number="1">
+      <attributes>
+        <divisions>2</divisions>
+        <key><fifths>-3</fifths><mode>minor</mode></key>
+      </attributes>
+      <harmony>
+        <root><root-step>C</root-step></root>
+        <kind>minor-seventh</kind>
+      </harmony>
+      <note><pitch><step>C</step><octave>5</octave></pitch><duration>4</duration><voice>1</voice></note>
+      <note><grace/><pitch><step>D</step><octave>5</octave></pitch><voice>1</voice></note>
+      <note>
+        <pitch><step>E</step><alter>-1</alter><octave>5</octave></pitch><duration>4</duration>
+        <tie type="start"/><voice>1</voice>
+      </note>
+      <backup><duration>8</duration></backup>
+      <note><pitch><step>C</step><octave>3</octave></pitch><duration>8</duration><voice>2</voice></note>
+      <note><chord/><pitch><step>G</step><octave>3</octave></pitch><duration>8</duration><voice>2</voice></note>
+    </measure>
+    <measure number="2">
+      <harmony>
+        <root><root-step>F</root-step></root>
+        <kind text="7">dominant</kind>
+        <degree><degree-value>9</degree-value><degree-alter>1</degree-alter><degree-type>add</degree-type></degree>
+        <offset>2</offset>
+      </harmony>
+      <note>
+        <pitch><step>E</step><alter>-1</alter><octave>5</octave></pitch><duration>2</duration>
+        <tie type="stop"/><voice>1</voice>
+      </note>
+      <note><rest/><duration>2</duration><voice>1</voice></note>
+      <forward><duration>2</duration></forward>
+      <note><cue/><pitch><step>A</step><octave>4</octave></pitch><duration>2</duration></note>
+    </measure>
+  </part>
+</score-partwise>
+`
+
+const timewise = `<?xml version="1.0" encoding="UTF-8"?>
+<score-timewise version="4.0">
+  <work><work-title>Duet</work-title></work>
+  <movement-title>I</movement-title>
+  <part-list>
+    <score-part id="P1"><part-name>Flute</part-name></score-part>
+    <score-part id="P2"><part-name>Cello</part-name></score-part>
+  </part-list>
+  <measure number="1">
+    <part id="P1">
+      <attributes><divisions>1</divisions><key><fifths>1</fifths></key></attributes>
+      <note><pitch><step>G</step><octave>5</octave></pitch><duration>4</duration></note>
+    </part>
+    <part id="P2">
+      <attributes><divisions>4</divisions></attributes>
+      <note><pitch><step>G</step><octave>2</octave></pitch><duration>8</duration></note>
+      <note><pitch><step>D</step><octave>3</octave></pitch><duration>8</duration></note>
+    </part>
+  </measure>
+  <measure number="2">
+    <part id="P1">
+      <note><pitch><step>F</step><alter>1</alter><octave>5</octave></pitch><duration>4</duration></note>
+    </part>
+    <part id="P2">
+      <note><pitch><step>D</step><octave>2</octave></pitch><duration>16</duration></note>
+    </part>
+  </measure>
+</score-timewise>
+`
+
+// mxl returns a compressed MusicXML file holding given files.
+func mxl(t *testing.T, files ...[2]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		Require(t, NoError(err))
+		_, err = fw.Write([]byte(f[1]))
+		Require(t, NoError(err))
+	}
+	Require(t, NoError(w.Close()))
+	return buf.String()
+}
+
+func TestRead(t *testing.T) {
+	cMinor := gohar.NewMinorKey(gohar.PitchClassC)
+	gMajor := gohar.NewMajorKey(gohar.PitchClassG)
+	partwiseNotes := []NoteEvent{
+		{Note: gohar.NoteC.Octave(1), Start: 0, Duration: 4, Measure: 1, Voice: "1"},
+		{Note: gohar.NoteC.Octave(-1), Start: 0, Duration: 8, Measure: 1, Voice: "2"},
+		{Note: gohar.NoteG.Octave(-1), Start: 0, Duration: 8, Measure: 1, Voice: "2"},
+		{Note: gohar.NoteE.Flat().Octave(1), Start: 4, Duration: 6, Measure: 1, Voice: "1"},
+	}
+	container := `<?xml version="1.0" encoding="UTF-8"?>
+<container><rootfiles><rootfile full-path="score/main.musicxml"/></rootfiles></container>`
+
+	testCases := []struct {
+		Name  string
+		Input string
+		Title string
+		Parts []Part
+	}{
+		{
+			"partwise",
+			partwise,
+			"Étude",
+			[]Part{{
+				ID:    "P1",
+				Name:  "Piano",
+				Notes: partwiseNotes,
+				Harmonies: []HarmonyEvent{
+					{Chord: gohar.Chord{Root: gohar.PitchClassC, Pattern: gohar.ChordPatternMinor7}, Start: 0, Measure: 1},
+					{Chord: gohar.Chord{Root: gohar.PitchClassF, Pattern: gohar.ChordPattern7.Add(gohar.PitchDiffAugmentedNinth)}, Start: 10, Measure: 2},
+				},
+				Keys: []KeyEvent{{Key: cMinor, Start: 0, Measure: 1}},
+			}},
+		},
+		{
+			"timewise",
+			timewise,
+			"Duet",
+			[]Part{
+				{
+					ID:   "P1",
+					Name: "Flute",
+					Notes: []NoteEvent{
+						{Note: gohar.NoteG.Octave(1), Start: 0, Duration: 16, Measure: 1, Voice: "1"},
+						{Note: gohar.NoteF.Sharp().Octave(1), Start: 16, Duration: 16, Measure: 2, Voice: "1"},
+					},
+					Keys: []KeyEvent{{Key: gMajor, Start: 0, Measure: 1}},
+				},
+				{
+					ID:   "P2",
+					Name: "Cello",
+					Notes: []NoteEvent{
+						{Note: gohar.NoteG.Octave(-2), Start: 0, Duration: 8, Measure: 1, Voice: "1"},
+						{Note: gohar.NoteD.Octave(-1), Start: 8, Duration: 8, Measure: 1, Voice: "1"},
+						{Note: gohar.NoteD.Octave(-2), Start: 16, Duration: 16, Measure: 2, Voice: "1"},
+					},
+				},
+			},
+		},
+		{
+			"compressed with container",
+			mxl(t,
+				[2]string{"META-INF/container.xml", container},
+				[2]string{"score/other.musicxml", timewise},
+				[2]string{"score/main.musicxml", partwise},
+			),
+			"Étude",
+			nil,
+		},
+		{
+			"compressed without container",
+			mxl(t, [2]string{"mimetype", "application/vnd.recordare.musicxml"}, [2]string{"score.xml", partwise}),
+			"Étude",
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			doc, err := Read(strings.NewReader(tc.Input))
+			Require(t, NoError(err))
+			Expect(t, Equal(tc.Title, doc.Title))
+			if tc.Parts == nil {
+				// compressed files hold the partwise document
+				Expect(t, SliceHasLength(1, doc.Parts), Equal(partwiseNotes, doc.Parts[0].Notes))
+				return
+			}
+			Expect(t, Equal(tc.Parts, doc.Parts))
+		})
+	}
+}
+
+func TestPartVoices(t *testing.T) {
+	doc, err := Read(strings.NewReader(partwise))
+	Require(t, NoError(err))
+	part := doc.Parts[0]
+	Expect(t,
+		Equal(2, doc.Divisions),
+		Equal([]string{"1", "2"}, part.Voices()),
+		SliceHasLength(2, part.Voice("1")),
+		SliceHasLength(2, part.Voice("2")),
+		IsEmptySlice(part.Voice("3")),
+	)
+}
+
+func TestDocumentSequence(t *testing.T) {
+	doc, err := Read(strings.NewReader(partwise))
+	Require(t, NoError(err))
+	dm, err := gohar.ParseChord("Dm")
+	Require(t, NoError(err))
+	g7, err := gohar.ParseChord("G7")
+	Require(t, NoError(err))
+
+	testCases := []struct {
+		Name string
+		Doc  *Document
+		Part Part
+		Want []string
+	}{
+		{
+			"partwise",
+			doc,
+			doc.Parts[0],
+			[]string{"0+1/2 C1 Cm7", "0+1 C-1 G-1", "1/2+3/4 E♭1", "5/4+0 F7♯9"},
+		},
+		{
+			"held notes and rests",
+			&Document{Divisions: 1},
+			Part{
+				Notes: []NoteEvent{
+					{Note: gohar.NoteC, Start: 0, Duration: 4, Voice: "1"},
+					{Note: gohar.NoteE, Start: 0, Duration: 4, Voice: "1"},
+					{Note: gohar.NoteG, Start: 6, Duration: 2, Voice: "1"},
+				},
+				Harmonies:     []HarmonyEvent{{Chord: dm, Start: 2}, {Chord: g7, Start: 4}},
+				TimeSignature: gohar.TimeSignatureWaltz,
+			},
+			[]string{"0+1/2 C0 E0~", "1/2+1/2 C0 E0 Dm", "1+1/2 G7", "3/2+1/2 G0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := tc.Doc.Sequence(tc.Part)
+			Expect(t,
+				Equal(tc.Part.TimeSignature, s.TimeSignature),
+				Equal(tc.Want, eventStrings(s)),
+			)
+		})
+	}
+}
+
+// eventStrings returns the times, notes and chord symbols of the events, and
+// "~" if they are tied.
+func eventStrings(s gohar.Sequence) []string {
+	var events []string
+	for _, e := range s.Events {
+		str := e.Start.String() + "+" + e.Duration.String()
+		for _, n := range e.Notes {
+			str += " " + n.String()
+		}
+		if e.Harmony != nil {
+			str += " " + e.Harmony.String()
+		}
+		if e.Tied {
+			str += "~"
+		}
+		events = append(events, str)
+	}
+	return events
+}
+
+func TestReadInvalid(t *testing.T) {
+	measure := func(content string) string {
+		return `<score-partwise><part id="P1"><measure>` + content + `</measure></part></score-partwise>`
+	}
+	harmony := func(value, alter int, kind string) string {
+		return fmt.Sprintf(`<harmony><root><root-step>C</root-step></root><kind>major</kind>`+
+			`<degree><degree-value>%d</degree-value><degree-alter>%d</degree-alter><degree-type>%s</degree-type></degree>`+
+			`</harmony>`, value, alter, kind)
+	}
+
+	testCases := []struct {
+		Name  string
+		Input string
+	}{
+		{"empty", ""},
+		{"not XML", "MThd"},
+		{"unexpected root", "<opus></opus>"},
+		{"unclosed element", `<score-partwise><part id="P1"><measure>`},
+		{"invalid step", measure(`<note><pitch><step>H</step><octave>4</octave></pitch></note>`)},
+		{"missing step", measure(`<note><pitch><octave>4</octave></pitch></note>`)},
+		{"missing fifths", measure(`<attributes><key><mode>major</mode></key></attributes>`)},
+		{"unknown mode", measure(`<attributes><key><fifths>0</fifths><mode>bebop</mode></key></attributes>`)},
+		{"added degree below the root", measure(harmony(1, -1, "add"))},
+		{"altered degree below the root", measure(harmony(1, -1, "alter"))},
+		{"added degree above two octaves", measure(harmony(15, 0, "add"))},
+		{"invalid archive", "PK\x03\x04 truncated"},
+		{"missing document", mxl(t, [2]string{"mimetype", "application/vnd.recordare.musicxml"})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tc.Input))
+			Expect(t, IsError(ErrInvalidFile, err))
+		})
+	}
+}
+
+func TestParseHarmony(t *testing.T) {
+	testCases := []struct {
+		Step  string
+		Alter float64
+		Kind  string
+		Text  string
+		Want  string
+	}{
+		{"B", -1, "major-seventh", "", "B♭maj7"},
+		{"F", 1, "half-diminished", "ø", "F♯m7♭5"},
+		{"C", 0, " suspended-fourth ", "", "Csus4"},
+		{"G", 0, "other", "7#11", "G7♯11"},
+		{"E", 0.4, "minor", "", "Em"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Want, func(t *testing.T) {
+			c, err := ParseHarmony(tc.Step, tc.Alter, tc.Kind, tc.Text)
+			Expect(t, NoError(err), Equal(tc.Want, c.String()))
+		})
+	}
+
+	_, err := ParseHarmony("C", 0, "other", "")
+	Expect(t, IsError(gohar.ErrInvalidChordSymbol, err))
+}
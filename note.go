@@ -76,6 +76,14 @@ func (n Note) Transpose(i Interval) Note {
 	}
 }
 
+// BaseOctave returns the octave of the note's base, regardless of its
+// alteration. It only differs from Oct for notes such as B♯ or C♭, whose pitch
+// crosses an octave boundary: for instance, B♯0 is written on the same staff
+// position as B0, but sounds like C1.
+func (n Note) BaseOctave() int8 {
+	return (n.Pitch() - n.Alt()).GetOctave()
+}
+
 // IsEnharmonic returns true if both notes have the same pitch.
 func (n Note) IsEnharmonic(note Note) bool {
 	return note.Pitch() == n.Pitch()
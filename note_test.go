@@ -105,6 +105,19 @@ func TestNoteTranspose(t *testing.T) {
 	)
 }
 
+func TestNoteBaseOctave(t *testing.T) {
+	Expect(t,
+		Equal(int8(0), NoteC.BaseOctave()),
+		Equal(int8(-2), NoteG.Octave(-2).BaseOctave()),
+		Equal(int8(0), NoteB.Sharp().BaseOctave()),
+		Equal(int8(1), NoteB.Sharp().Oct),
+		Equal(int8(0), NoteC.Flat().BaseOctave()),
+		Equal(int8(-1), NoteC.Flat().Oct),
+		Equal(int8(0), NoteB.DoubleSharp().BaseOctave()),
+		Equal(int8(3), NoteD.DoubleFlat().Octave(3).BaseOctave()),
+	)
+}
+
 func TestNoteIsEnharmonic(t *testing.T) {
 	Expect(t,
 		Equal(false, NoteC.IsEnharmonic(NoteD)),
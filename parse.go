@@ -3,6 +3,7 @@ package gohar
 import (
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ const (
 
 var (
 	noteRegexp        = regexp.MustCompile(`^([a-gA-G])(#|b|##|bb|♭|♯|𝄫|𝄪|♮)?([+-]?\d)?$`)
+	intervalRegexp    = regexp.MustCompile(`^(-)?(?:(AA|A|P|M|m|dd|d)|(##|#|bb|b|♭|♯|𝄫|𝄪))?(\d+)$`)
 	toUnicodeReplacer = strings.NewReplacer(
 		"bb", AltDoubleFlat,
 		"##", AltDoubleSharp,
@@ -27,8 +29,9 @@ var (
 	)
 	_ = toUnicodeReplacer
 
-	ErrCannotParseNote   = errors.New("cannot parse note")
-	ErrUnknownAlteration = errors.New("unknown alteration")
+	ErrCannotParseNote     = errors.New("cannot parse note")
+	ErrCannotParseInterval = errors.New("cannot parse interval")
+	ErrUnknownAlteration   = errors.New("unknown alteration")
 )
 
 func ParseNote(input string) (Note, error) {
@@ -52,6 +55,54 @@ func ParsePitch(input string) (Pitch, error) {
 	}
 }
 
+// ParseInterval parses an interval, either written with its quality and number
+// (e.g. "M3", "P5", "A4", "d7", "m9", "AA4"), or as an altered degree relative to
+// the major scale (e.g. "3", "b13", "#11", "bb7").
+// A leading minus sign denotes a descending interval (e.g. "-P5").
+func ParseInterval(input string) (Interval, error) {
+	match := intervalRegexp.FindStringSubmatch(input)
+	if len(match) == 0 {
+		return Interval{}, fmt.Errorf("%w: %q", ErrCannotParseInterval, input)
+	}
+	number, err := strconv.Atoi(match[4])
+	if err != nil || number < 1 {
+		return Interval{}, fmt.Errorf("%w: %q", ErrCannotParseInterval, input)
+	}
+	var interval Interval
+	if match[2] != "" {
+		quality := qualityFromAbbreviation(match[2])
+		if interval, err = NewInterval(quality, number); err != nil {
+			return Interval{}, err
+		}
+	} else {
+		quality := QualityMajor
+		if isPerfectDegree(number - 1) {
+			quality = QualityPerfect
+		}
+		if interval, err = NewInterval(quality, number); err != nil {
+			return Interval{}, err
+		}
+		alt, _ := ParseAlteration(match[3])
+		if int(interval.PitchDiff)+int(alt) > math.MaxInt8 {
+			return Interval{}, fmt.Errorf("%w: %q is too wide", ErrInvalidInterval, input)
+		}
+		interval.PitchDiff += alt
+	}
+	if match[1] != "" {
+		interval = interval.Down()
+	}
+	return interval, nil
+}
+
+func qualityFromAbbreviation(abbr string) IntervalQuality {
+	for q, a := range qualityAbbreviations {
+		if a == abbr && a != "" {
+			return IntervalQuality(q)
+		}
+	}
+	return QualityInvalid
+}
+
 func ParseAlteration(alt string) (Pitch, error) {
 	switch alt {
 	case "bb", AltDoubleFlat:
@@ -68,3 +119,269 @@ func ParseAlteration(alt string) (Pitch, error) {
 		return 0, ErrUnknownAlteration
 	}
 }
+
+// ParseChord parses a chord symbol such as "C", "Cm7b5/G", "F#7alt", "BbΔ9#11" or "Eb6/9".
+//
+// A chord symbol is made of:
+//   - a root (C, F#, Bb...),
+//   - an optional quality (m, min, -, maj, M, Δ, dim, o, °, ø, aug, +),
+//   - an optional extension (5, 6, 6/9, 7, 9, 11, 13),
+//   - any number of alterations and modifiers (b5, #9, b13, alt, sus4, add9, omit5...),
+//   - an optional slash bass (/G).
+//
+// Like [ChordPattern.Unpack], the resulting pattern lays extensions (9th and above)
+// out in the second octave.
+//
+// Errors wrap ErrInvalidChordSymbol and tell which part of the symbol failed.
+func ParseChord(input string) (Chord, error) {
+	var chord Chord
+	root, rest, ok := parseChordRoot(input)
+	if !ok {
+		return chord, wrapErrorf(ErrInvalidChordSymbol, "%q: cannot parse root", input)
+	}
+	chord.Root = root
+	if i := strings.LastIndexByte(rest, '/'); i >= 0 && startsWithNoteLetter(rest[i+1:]) {
+		bass, tail, ok := parseChordRoot(rest[i+1:])
+		if !ok || tail != "" {
+			return chord, wrapErrorf(ErrInvalidChordSymbol, "%q: cannot parse bass %q", input, rest[i+1:])
+		}
+		chord.Bass = bass
+		rest = rest[:i]
+	}
+	pattern, err := parseChordSuffix(rest)
+	if err != nil {
+		return chord, wrapErrorf(ErrInvalidChordSymbol, "%q: %v", input, err)
+	}
+	chord.Pattern = pattern
+	return chord, nil
+}
+
+var chordRootAlterations = []string{"##", "bb", "#", "b", AltDoubleSharp, AltDoubleFlat, AltSharp, AltFlat}
+
+func parseChordRoot(input string) (PitchClass, string, bool) {
+	if !startsWithNoteLetter(input) {
+		return 0, input, false
+	}
+	base := strings.ToUpper(input[:1])[0]
+	rest := input[1:]
+	var alt Pitch
+	for _, tok := range chordRootAlterations {
+		if strings.HasPrefix(rest, tok) {
+			alt, _ = ParseAlteration(tok)
+			rest = rest[len(tok):]
+			break
+		}
+	}
+	pc, err := NewPitchClassFromChar(base, alt)
+	return pc, rest, err == nil
+}
+
+func startsWithNoteLetter(input string) bool {
+	if input == "" {
+		return false
+	}
+	c := input[0]
+	return ('A' <= c && c <= 'G') || ('a' <= c && c <= 'g')
+}
+
+// chordSuffixParser parses the part of a chord symbol that follows the root,
+// e.g. "m7b5" in "Cm7b5".
+type chordSuffixParser struct {
+	input        string
+	rest         string
+	pattern      ChordPattern
+	majorSeventh bool
+}
+
+func parseChordSuffix(input string) (ChordPattern, error) {
+	p := &chordSuffixParser{
+		input:   input,
+		rest:    input,
+		pattern: ChordPatternMajor,
+	}
+	p.parseQuality()
+	p.parseExtension()
+	for p.rest != "" {
+		if err := p.parseModifier(); err != nil {
+			return 0, err
+		}
+	}
+	return p.pattern, nil
+}
+
+func (p *chordSuffixParser) consume(tokens ...string) bool {
+	for _, tok := range tokens {
+		if strings.HasPrefix(p.rest, tok) {
+			p.rest = p.rest[len(tok):]
+			return true
+		}
+	}
+	return false
+}
+
+func (p *chordSuffixParser) parseQuality() {
+	if strings.HasPrefix(p.rest, "omit") {
+		return
+	}
+	switch {
+	case p.consume("Δ", "∆", "^"):
+		p.majorSeventh = true
+		p.pattern = p.pattern.Add(PitchDiffMajorSeventh)
+	case p.consume("maj", "Maj", "MA", "M"):
+		p.majorSeventh = true
+	case p.consume("ø", "Ø"):
+		p.pattern = ChordPatternMinor7Flat5
+	case p.consume("dim", "°", "o"):
+		p.pattern = ChordPatternDiminished
+	case p.consume("aug", "+"):
+		p.pattern = ChordPatternAugmented
+		// "C+5" is an augmented triad, not a power chord
+		p.consume("5")
+	case p.consume("min", "mi", "m", "-"):
+		p.pattern = ChordPatternMinor
+		switch {
+		case p.consume("Δ", "∆", "^"):
+			p.majorSeventh = true
+			p.pattern = p.pattern.Add(PitchDiffMajorSeventh)
+		case p.consume("maj", "Maj", "M"):
+			p.majorSeventh = true
+		}
+	}
+}
+
+func (p *chordSuffixParser) parseExtension() {
+	switch {
+	case p.consume("6/9", "69"):
+		p.pattern = p.pattern.Add(PitchDiffMajorSixth).Add(PitchDiffMajorNinth)
+	case p.consume("6"):
+		p.pattern = p.pattern.Add(PitchDiffMajorSixth)
+	case p.consume("7"):
+		p.pattern = p.pattern.Add(p.seventh())
+	case p.consume("9"):
+		p.pattern = p.pattern.Add(p.seventh()).Add(PitchDiffMajorNinth)
+	case p.consume("11"):
+		p.pattern = p.pattern.Add(p.seventh()).Add(PitchDiffMajorNinth).Add(PitchDiffPerfectEleventh)
+	case p.consume("13"):
+		p.pattern = p.pattern.Add(p.seventh()).Add(PitchDiffMajorNinth).Add(PitchDiffMajorThirteenth)
+	case p.consume("5"):
+		p.pattern = ChordPattern(0).Add(PitchDiffUnisson).Add(PitchDiffPerfectFifth)
+	case p.consume("2"):
+		p.pattern = p.pattern.Add(PitchDiffMajorSecond)
+	}
+}
+
+// seventh returns the kind of seventh implied by the chord's quality.
+func (p *chordSuffixParser) seventh() Pitch {
+	switch {
+	case p.majorSeventh:
+		return PitchDiffMajorSeventh
+	case p.pattern == ChordPatternDiminished:
+		return PitchDiffDiminishedSeventh
+	default:
+		return PitchDiffMinorSeventh
+	}
+}
+
+func (p *chordSuffixParser) parseModifier() error {
+	start := p.rest
+	switch {
+	case p.consume(" ", "(", ")", ","):
+	case p.consume("alt"):
+		p.pattern = ChordPattern7No5.Add(PitchDiffAugmentedNinth).Add(PitchDiffMinorThirteenth)
+	case p.consume("sus2"):
+		p.sus(PitchDiffMajorSecond)
+	case p.consume("sus4", "sus"):
+		p.sus(PitchDiffPerfectFourth)
+	case p.consume("maj7", "Maj7", "M7", "Δ7", "∆7", "Δ", "∆"):
+		p.pattern = p.pattern.Omit(PitchDiffMinorSeventh).Add(PitchDiffMajorSeventh)
+	case p.consume("add"):
+		degree, err := p.parseDegree()
+		if err != nil {
+			return err
+		}
+		p.pattern = p.pattern.Add(degree)
+	case p.consume("omit", "no"):
+		degree, err := p.parseDegree()
+		if err != nil {
+			return err
+		}
+		p.pattern = p.pattern.Omit(degree)
+		if degree == PitchDiffMajorThird {
+			p.pattern = p.pattern.Omit(PitchDiffMinorThird)
+		}
+	default:
+		degree, err := p.parseDegree()
+		if err != nil {
+			return fmt.Errorf("unexpected %q in suffix %q", start, p.input)
+		}
+		// An altered degree replaces its natural counterpart.
+		p.pattern = p.pattern.Omit(naturalDegree(degree)).Add(degree)
+	}
+	return nil
+}
+
+func (p *chordSuffixParser) sus(degree Pitch) {
+	p.pattern = p.pattern.
+		Omit(PitchDiffMinorThird).
+		Omit(PitchDiffMajorThird).
+		Add(degree)
+}
+
+// chordDegrees maps chord symbol degrees to their natural pitch
+// (major or perfect, except for the 7th which is minor).
+var chordDegrees = map[int]Pitch{
+	1:  PitchDiffUnisson,
+	2:  PitchDiffMajorSecond,
+	3:  PitchDiffMajorThird,
+	4:  PitchDiffPerfectFourth,
+	5:  PitchDiffPerfectFifth,
+	6:  PitchDiffMajorSixth,
+	7:  PitchDiffMinorSeventh,
+	9:  PitchDiffMajorNinth,
+	11: PitchDiffPerfectEleventh,
+	13: PitchDiffMajorThirteenth,
+}
+
+// naturalDegree returns the natural pitch of the degree that is closest to given pitch.
+func naturalDegree(p Pitch) Pitch {
+	switch p {
+	case PitchDiffMinorThird:
+		return PitchDiffMajorThird
+	case PitchDiffDiminishedFifth, PitchDiffAugmentedFifth:
+		return PitchDiffPerfectFifth
+	case PitchDiffMinorNinth, PitchDiffAugmentedNinth:
+		return PitchDiffMajorNinth
+	case PitchDiffAugmentedEleventh:
+		return PitchDiffPerfectEleventh
+	case PitchDiffMinorThirteenth:
+		return PitchDiffMajorThirteenth
+	default:
+		return p
+	}
+}
+
+// parseDegree parses an optionally altered degree, such as "9", "b13" or "#11".
+func (p *chordSuffixParser) parseDegree() (Pitch, error) {
+	start := p.rest
+	var alt Pitch
+	switch {
+	case p.consume("b", AltFlat, "-"):
+		alt = -1
+	case p.consume("#", AltSharp, "+"):
+		alt = 1
+	}
+	n := 0
+	for p.rest != "" && '0' <= p.rest[0] && p.rest[0] <= '9' {
+		n = 10*n + int(p.rest[0]-'0')
+		p.rest = p.rest[1:]
+	}
+	degree, ok := chordDegrees[n]
+	if !ok {
+		return 0, fmt.Errorf("invalid degree %d in suffix %q", n, p.input)
+	}
+	// the degree must fit in a ChordPattern: a ♭1 would be below the root
+	if degree+alt < 0 || degree+alt > 23 {
+		return 0, fmt.Errorf("invalid degree %q in suffix %q", start[:len(start)-len(p.rest)], p.input)
+	}
+	return degree + alt, nil
+}
@@ -89,3 +89,182 @@ func TestParseAlteration(t *testing.T) {
 		Expect(t, tc.Check(got, err))
 	}
 }
+
+func TestParseChord(t *testing.T) {
+	isError := HasError[Chord]
+	isChord := AsCheckFunc(func(want, got Chord) error {
+		return Equal(want, got)
+	})
+	c := PitchClassC
+
+	testCases := []struct {
+		Input string
+		Check CheckFunc[Chord]
+	}{
+		{"", isError(ErrInvalidChordSymbol)},
+		{"H7", isError(ErrInvalidChordSymbol)},
+		{"Cxyz", isError(ErrInvalidChordSymbol)},
+		{"C7b", isError(ErrInvalidChordSymbol)},
+		{"Cadd8", isError(ErrInvalidChordSymbol)},
+		{"C7b1", isError(ErrInvalidChordSymbol)},
+		{"Caddb1", isError(ErrInvalidChordSymbol)},
+		{"C(b1)", isError(ErrInvalidChordSymbol)},
+		{"C/X", isError(ErrInvalidChordSymbol)},
+		{"C/Gm", isError(ErrInvalidChordSymbol)},
+		{"C", isChord(Chord{Root: c, Pattern: ChordPatternMajor})},
+		{"Cmaj", isChord(Chord{Root: c, Pattern: ChordPatternMajor})},
+		{"F#", isChord(Chord{Root: PitchClassF.Sharp(), Pattern: ChordPatternMajor})},
+		{"Bb", isChord(Chord{Root: PitchClassB.Flat(), Pattern: ChordPatternMajor})},
+		{"Cm", isChord(Chord{Root: c, Pattern: ChordPatternMinor})},
+		{"C-7", isChord(Chord{Root: c, Pattern: ChordPatternMinor7})},
+		{"Cmi7", isChord(Chord{Root: c, Pattern: ChordPatternMinor7})},
+		{"CΔ", isChord(Chord{Root: c, Pattern: ChordPatternMajor7})},
+		{"CΔ7", isChord(Chord{Root: c, Pattern: ChordPatternMajor7})},
+		{"CM7", isChord(Chord{Root: c, Pattern: ChordPatternMajor7})},
+		{"Cmaj7", isChord(Chord{Root: c, Pattern: ChordPatternMajor7})},
+		{"C7", isChord(Chord{Root: c, Pattern: ChordPattern7})},
+		{"Cm7b5", isChord(Chord{Root: c, Pattern: ChordPatternMinor7Flat5})},
+		{"Cø", isChord(Chord{Root: c, Pattern: ChordPatternMinor7Flat5})},
+		{"Cø7", isChord(Chord{Root: c, Pattern: ChordPatternMinor7Flat5})},
+		{"Co7", isChord(Chord{Root: c, Pattern: ChordPatternDiminished7})},
+		{"C°7", isChord(Chord{Root: c, Pattern: ChordPatternDiminished7})},
+		{"Cdim", isChord(Chord{Root: c, Pattern: ChordPatternDiminished})},
+		{"C+", isChord(Chord{Root: c, Pattern: ChordPatternAugmented})},
+		{"Caug", isChord(Chord{Root: c, Pattern: ChordPatternAugmented})},
+		{"C+5", isChord(Chord{Root: c, Pattern: ChordPatternAugmented})},
+		{"Caug5", isChord(Chord{Root: c, Pattern: ChordPatternAugmented})},
+		{"Csus4", isChord(Chord{Root: c, Pattern: ChordPatternSus4})},
+		{"Csus", isChord(Chord{Root: c, Pattern: ChordPatternSus4})},
+		{"C5", isChord(Chord{Root: c, Pattern: 0b10000001})},
+		{
+			"CmMaj7",
+			isChord(Chord{Root: c, Pattern: ChordPatternMinor.Add(PitchDiffMajorSeventh)}),
+		},
+		{
+			"Cm(maj7)",
+			isChord(Chord{Root: c, Pattern: ChordPatternMinor.Add(PitchDiffMajorSeventh)}),
+		},
+		{
+			"C6/9",
+			isChord(Chord{Root: c, Pattern: ChordPatternMajor.Add(PitchDiffMajorSixth).Add(PitchDiffMajorNinth)}),
+		},
+		{
+			"Cm69",
+			isChord(Chord{Root: c, Pattern: ChordPatternMinor.Add(PitchDiffMajorSixth).Add(PitchDiffMajorNinth)}),
+		},
+		{
+			"Cmaj9#11",
+			isChord(Chord{
+				Root:    c,
+				Pattern: ChordPatternMajor7.Add(PitchDiffMajorNinth).Add(PitchDiffAugmentedEleventh),
+			}),
+		},
+		{
+			"C7alt",
+			isChord(Chord{
+				Root:    c,
+				Pattern: ChordPattern7No5.Add(PitchDiffAugmentedNinth).Add(PitchDiffMinorThirteenth),
+			}),
+		},
+		{
+			"C13b9",
+			isChord(Chord{
+				Root:    c,
+				Pattern: ChordPattern7.Add(PitchDiffMinorNinth).Add(PitchDiffMajorThirteenth),
+			}),
+		},
+		{
+			"C7(b9, #11)",
+			isChord(Chord{
+				Root:    c,
+				Pattern: ChordPattern7.Add(PitchDiffMinorNinth).Add(PitchDiffAugmentedEleventh),
+			}),
+		},
+		{
+			"C7#5",
+			isChord(Chord{Root: c, Pattern: ChordPattern7No5.Add(PitchDiffAugmentedFifth)}),
+		},
+		{
+			"C9sus4",
+			isChord(Chord{
+				Root:    c,
+				Pattern: ChordPatternSus4.Add(PitchDiffMinorSeventh).Add(PitchDiffMajorNinth),
+			}),
+		},
+		{
+			"Cadd9",
+			isChord(Chord{Root: c, Pattern: ChordPatternMajor.Add(PitchDiffMajorNinth)}),
+		},
+		{
+			"Cmadd9",
+			isChord(Chord{Root: c, Pattern: ChordPatternMinor.Add(PitchDiffMajorNinth)}),
+		},
+		{
+			"C7omit5",
+			isChord(Chord{Root: c, Pattern: ChordPattern7No5}),
+		},
+		{
+			"Cm7b5/G",
+			isChord(Chord{Root: c, Pattern: ChordPatternMinor7Flat5, Bass: PitchClassG}),
+		},
+		{
+			"Eb6/9/Bb",
+			isChord(Chord{
+				Root:    PitchClassE.Flat(),
+				Pattern: ChordPatternMajor.Add(PitchDiffMajorSixth).Add(PitchDiffMajorNinth),
+				Bass:    PitchClassB.Flat(),
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			got, err := ParseChord(tc.Input)
+			Expect(t, tc.Check(got, err))
+		})
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	isError := HasError[Interval]
+	isInterval := AsCheckFunc(func(want, got Interval) error {
+		return Equal(want, got)
+	})
+	testCases := []struct {
+		Input string
+		Check CheckFunc[Interval]
+	}{
+		{"", isError(ErrCannotParseInterval)},
+		{"M", isError(ErrCannotParseInterval)},
+		{"X3", isError(ErrCannotParseInterval)},
+		{"P3", isError(ErrInvalidInterval)},
+		{"M0", isError(ErrCannotParseInterval)},
+		{"P1", isInterval(IntUnisson)},
+		{"m3", isInterval(IntMinorThird)},
+		{"M3", isInterval(IntMajorThird)},
+		{"A4", isInterval(IntAugmentedFourth)},
+		{"d5", isInterval(IntDiminishedFifth)},
+		{"dd4", isInterval(Interval{3, 3})},
+		{"P8", isInterval(IntOctave)},
+		{"-P5", isInterval(IntPerfectFifth.Down())},
+		{"3", isInterval(IntMajorThird)},
+		{"b3", isInterval(IntMinorThird)},
+		{"#4", isInterval(IntAugmentedFourth)},
+		{"bb7", isInterval(IntDiminishedSeventh)},
+		{"b9", isInterval(IntMinorNinth)},
+		{"#11", isInterval(IntAugmentedEleventh)},
+		{"♭13", isInterval(IntMinorThirteenth)},
+		{"-b3", isInterval(IntMinorThird.Down())},
+		{"P75", isInterval(Interval{74, 127})},
+		{"99", isError(ErrInvalidInterval)},
+		{"A75", isError(ErrInvalidInterval)},
+		{"#75", isError(ErrInvalidInterval)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			got, err := ParseInterval(tc.Input)
+			Expect(t, tc.Check(got, err))
+		})
+	}
+}
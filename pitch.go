@@ -38,6 +38,29 @@ func (p Pitch) AtOctave(oct int8) Pitch {
 	return p.Normalize() + Pitch(12*oct)
 }
 
+// MIDI returns the MIDI note number of the pitch. Middle C (pitch 0) is MIDI
+// note 60.
+//
+// ErrInvalidMIDINote is returned if the pitch is out of the MIDI range, i.e.
+// [-60; 67].
+func (p Pitch) MIDI() (uint8, error) {
+	n := int(p) + 60
+	if n < 0 || n > 127 {
+		return 0, wrapErrorf(ErrInvalidMIDINote, "pitch %d is out of range", p)
+	}
+	return uint8(n), nil
+}
+
+// PitchFromMIDI returns the pitch of given MIDI note number.
+//
+// ErrInvalidMIDINote is returned if the note number is not in the range [0; 127].
+func PitchFromMIDI(n int) (Pitch, error) {
+	if n < 0 || n > 127 {
+		return 0, wrapErrorf(ErrInvalidMIDINote, "%d", n)
+	}
+	return Pitch(n - 60), nil
+}
+
 const (
 	PitchC            Pitch = 0
 	PitchBSharp       Pitch = 0
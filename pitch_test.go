@@ -87,3 +87,34 @@ func TestPitchGetOctave(t *testing.T) {
 		})
 	}
 }
+
+func TestPitchMIDI(t *testing.T) {
+	testCases := []struct {
+		Pitch
+		Want uint8
+	}{
+		{0, 60},
+		{PitchA, 69},
+		{-60, 0},
+		{67, 127},
+		{-39, 21},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.Pitch), func(t *testing.T) {
+			got, err := tc.Pitch.MIDI()
+			Expect(t, NoError(err), Equal(tc.Want, got))
+			p, err := PitchFromMIDI(int(got))
+			Expect(t, NoError(err), pitchEqual(tc.Pitch, p))
+		})
+	}
+
+	for _, p := range []Pitch{-61, 68, 127, -128} {
+		_, err := p.MIDI()
+		Expect(t, IsErrorf(ErrInvalidMIDINote, err, "%d", p))
+	}
+	for _, n := range []int{-1, 128} {
+		_, err := PitchFromMIDI(n)
+		Expect(t, IsErrorf(ErrInvalidMIDINote, err, "%d", n))
+	}
+}
@@ -0,0 +1,228 @@
+package gohar
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// DefaultBeatsPerBar is the number of beats per bar used by progressions that
+// don't specify it.
+const DefaultBeatsPerBar = 4
+
+// A ProgressionChord is a chord of a [Progression], with its duration.
+type ProgressionChord struct {
+	Chord
+	// Beats is the duration of the chord, in beats.
+	Beats int
+}
+
+// A Progression is an ordered list of chords, each lasting a number of beats.
+type Progression struct {
+	Chords []ProgressionChord
+	// BeatsPerBar is the number of beats in each bar.
+	// If it isn't positive, DefaultBeatsPerBar is used.
+	BeatsPerBar int
+}
+
+// ParseProgression parses a chord progression written as a sequence of bars
+// separated by "|", such as "| Dm7 G7 | Cmaj7 % |", with given number of beats
+// per bar (or DefaultBeatsPerBar if it isn't positive).
+//
+// The beats of each bar are evenly shared between its symbols. The symbols "."
+// and "%" extend the previous chord, and a bar that only contains "%" repeats the
+// previous bar. Without any "|", each symbol lasts a whole bar.
+//
+// ErrInvalidProgression is returned if the bars cannot be evenly divided, or if
+// a symbol cannot be parsed.
+func ParseProgression(input string, beatsPerBar int) (Progression, error) {
+	return parseProgression(input, beatsPerBar, ParseChord)
+}
+
+// ParseRomanProgression is like [ParseProgression], with chords written as roman
+// numerals in given key (see [ParseRomanNumeral]), such as "ii7 V7 Imaj7".
+func ParseRomanProgression(input string, key Scale, beatsPerBar int) (Progression, error) {
+	return parseProgression(input, beatsPerBar, func(s string) (Chord, error) {
+		return ParseRomanNumeral(s, key)
+	})
+}
+
+func parseProgression(input string, beatsPerBar int, parseChord func(string) (Chord, error)) (Progression, error) {
+	p := Progression{BeatsPerBar: beatsPerBar}
+	beatsPerBar = p.beatsPerBar()
+
+	var bars [][]string
+	if strings.Contains(input, "|") {
+		for bar := range strings.SplitSeq(input, "|") {
+			if symbols := strings.Fields(bar); len(symbols) > 0 {
+				bars = append(bars, symbols)
+			}
+		}
+	} else {
+		for symbol := range strings.FieldsSeq(input) {
+			bars = append(bars, []string{symbol})
+		}
+	}
+
+	var previousBar []ProgressionChord
+	for n, symbols := range bars {
+		if len(symbols) == 1 && symbols[0] == "%" && previousBar != nil {
+			p.Chords = append(p.Chords, previousBar...)
+			continue
+		}
+		if beatsPerBar%len(symbols) != 0 {
+			return Progression{}, wrapErrorf(ErrInvalidProgression,
+				"bar %d: cannot divide %d beats between %d chords", n+1, beatsPerBar, len(symbols),
+			)
+		}
+		beats := beatsPerBar / len(symbols)
+		start := len(p.Chords)
+		for _, symbol := range symbols {
+			if symbol == "." || symbol == "%" {
+				if len(p.Chords) == 0 {
+					return Progression{}, wrapErrorf(ErrInvalidProgression,
+						"bar %d: %q without a previous chord", n+1, symbol,
+					)
+				}
+				p.Chords[len(p.Chords)-1].Beats += beats
+				continue
+			}
+			chord, err := parseChord(symbol)
+			if err != nil {
+				return Progression{}, wrapErrorf(ErrInvalidProgression, "bar %d: %s", n+1, err)
+			}
+			p.Chords = append(p.Chords, ProgressionChord{chord, beats})
+		}
+		// the bar only holds the chords that start within it
+		previousBar = slices.Clone(p.Chords[start:])
+		if symbols[0] == "." || symbols[0] == "%" {
+			previousBar = nil
+		}
+	}
+	return p, nil
+}
+
+func (p Progression) beatsPerBar() int {
+	if p.BeatsPerBar <= 0 {
+		return DefaultBeatsPerBar
+	}
+	return p.BeatsPerBar
+}
+
+// Beats returns the total duration of the progression, in beats.
+func (p Progression) Beats() int {
+	var total int
+	for _, c := range p.Chords {
+		total += c.Beats
+	}
+	return total
+}
+
+// All iterates over the chords of the progression, with their duration.
+func (p Progression) All() iter.Seq[ProgressionChord] {
+	return func(yield func(ProgressionChord) bool) {
+		for _, c := range p.Chords {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// Bars iterates over the bars of the progression. Chords that span several bars
+// are split at bar lines, so that the durations of each bar add up to the number
+// of beats per bar (except for the last bar, that may be incomplete).
+func (p Progression) Bars() iter.Seq[[]ProgressionChord] {
+	return func(yield func([]ProgressionChord) bool) {
+		for bar := range p.bars() {
+			chords := make([]ProgressionChord, len(bar))
+			for i, s := range bar {
+				chords[i] = s.ProgressionChord
+			}
+			if !yield(chords) {
+				return
+			}
+		}
+	}
+}
+
+// A barSegment is the part of a chord that lies within a bar.
+type barSegment struct {
+	ProgressionChord
+	// tied is true if the chord started in a previous bar.
+	tied bool
+}
+
+func (p Progression) bars() iter.Seq[[]barSegment] {
+	return func(yield func([]barSegment) bool) {
+		beatsPerBar := p.beatsPerBar()
+		var (
+			bar  []barSegment
+			left = beatsPerBar
+		)
+		for _, c := range p.Chords {
+			tied := false
+			for c.Beats > 0 {
+				beats := min(c.Beats, left)
+				bar = append(bar, barSegment{ProgressionChord{c.Chord, beats}, tied})
+				c.Beats -= beats
+				left -= beats
+				tied = true
+				if left == 0 {
+					if !yield(bar) {
+						return
+					}
+					bar, left = nil, beatsPerBar
+				}
+			}
+		}
+		if len(bar) > 0 {
+			yield(bar)
+		}
+	}
+}
+
+// Transpose transposes every chord of the progression by given interval.
+func (p Progression) Transpose(i Interval) Progression {
+	chords := make([]ProgressionChord, len(p.Chords))
+	for n, c := range p.Chords {
+		chords[n] = ProgressionChord{c.Chord.Transpose(i), c.Beats}
+	}
+	p.Chords = chords
+	return p
+}
+
+// String returns the progression written with bars, such as "| Dm7 G7 | Cmaj7 . |",
+// in a form that can be parsed back with [ParseProgression] if the progression
+// is made of whole bars. Each bar is divided in even slots, and "." marks the
+// slots where the previous chord goes on.
+func (p Progression) String() string {
+	var sb strings.Builder
+	for bar := range p.bars() {
+		slot := 0
+		for _, s := range bar {
+			slot = gcd(slot, s.Beats)
+		}
+		sb.WriteString("|")
+		for _, s := range bar {
+			for n := range s.Beats / slot {
+				sb.WriteString(" ")
+				if n == 0 && !s.tied {
+					sb.WriteString(s.Chord.String())
+				} else {
+					sb.WriteString(".")
+				}
+			}
+		}
+		sb.WriteString(" ")
+	}
+	sb.WriteString("|")
+	return sb.String()
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,127 @@
+package gohar
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func chordFromSymbol(t *testing.T, symbol string) Chord {
+	t.Helper()
+	chord, err := ParseChord(symbol)
+	Require(t, NoError(err))
+	return chord
+}
+
+func TestParseProgression(t *testing.T) {
+	testCases := []struct {
+		Input       string
+		BeatsPerBar int
+		Want        []string
+		Beats       []int
+		String      string
+	}{
+		{
+			"| Dm7 G7 | Cmaj7 % |", 0,
+			[]string{"Dm7", "G7", "Cmaj7"}, []int{2, 2, 4},
+			"| Dm7 G7 | Cmaj7 |",
+		},
+		{
+			"Dm7 G7 Cmaj7", 0,
+			[]string{"Dm7", "G7", "Cmaj7"}, []int{4, 4, 4},
+			"| Dm7 | G7 | Cmaj7 |",
+		},
+		{
+			"| C | . | F G | % |", 0,
+			[]string{"C", "F", "G", "F", "G"}, []int{8, 2, 2, 2, 2},
+			"| C | . | F G | F G |",
+		},
+		{
+			"|| Am . . E7 | Am |", 4,
+			[]string{"Am", "E7", "Am"}, []int{3, 1, 4},
+			"| Am . . E7 | Am |",
+		},
+		{
+			"| C F G |", 3,
+			[]string{"C", "F", "G"}, []int{1, 1, 1},
+			"| C F G |",
+		},
+		{
+			"| C/E | Dm7/C . . . |", 4,
+			[]string{"C/E", "Dm7/C"}, []int{4, 4},
+			"| C/E | Dm7/C |",
+		},
+		{"", 0, nil, nil, "|"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			p, err := ParseProgression(tc.Input, tc.BeatsPerBar)
+			Require(t, NoError(err))
+			var want []ProgressionChord
+			for i, symbol := range tc.Want {
+				want = append(want, ProgressionChord{chordFromSymbol(t, symbol), tc.Beats[i]})
+			}
+			Expect(t,
+				Equal(want, slices.Collect(p.All())),
+				Equal(tc.String, p.String()),
+			)
+			again, err := ParseProgression(p.String(), tc.BeatsPerBar)
+			Expect(t, NoError(err), Equal(p, again))
+		})
+	}
+
+	for _, input := range []string{
+		"| C F G |",
+		"| . | C |",
+		"| X7 |",
+	} {
+		_, err := ParseProgression(input, 4)
+		Expect(t, IsErrorf(ErrInvalidProgression, err, "%q", input))
+	}
+}
+
+func TestParseRomanProgression(t *testing.T) {
+	p, err := ParseRomanProgression("ii7 V7 Imaj7", NewMajorKey(PitchClassB.Flat()).Scale, 4)
+	Require(t, NoError(err))
+	Expect(t, Equal("| Cm7 | F7 | B♭maj7 |", p.String()))
+
+	p, err = ParseRomanProgression("| i V7/V | V7 |", NewMinorKey(PitchClassA).Scale, 2)
+	Require(t, NoError(err))
+	Expect(t, Equal("| Am B7 | E7 |", p.String()))
+
+	_, err = ParseRomanProgression("ii7 IX", NewMajorKey(PitchClassC).Scale, 4)
+	Expect(t, IsError(ErrInvalidProgression, err))
+}
+
+func TestProgressionTranspose(t *testing.T) {
+	p, err := ParseProgression("| Dm7 G7 | Cmaj7/E . |", 4)
+	Require(t, NoError(err))
+	transposed := p.Transpose(IntMinorThird)
+	Expect(t,
+		Equal("| Fm7 B♭7 | E♭maj7/G |", transposed.String()),
+		Equal("| Dm7 G7 | Cmaj7/E |", p.String()),
+		Equal(p.Beats(), transposed.Beats()),
+	)
+}
+
+func TestProgressionBars(t *testing.T) {
+	p := Progression{
+		Chords: []ProgressionChord{
+			{chordFromSymbol(t, "C"), 3},
+			{chordFromSymbol(t, "G7"), 4},
+		},
+		BeatsPerBar: 3,
+	}
+	bars := slices.Collect(p.Bars())
+	Expect(t,
+		Equal(7, p.Beats()),
+		Equal([][]ProgressionChord{
+			{{chordFromSymbol(t, "C"), 3}},
+			{{chordFromSymbol(t, "G7"), 3}},
+			{{chordFromSymbol(t, "G7"), 1}},
+		}, bars),
+		Equal("| C | G7 | . |", p.String()),
+	)
+}
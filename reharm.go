@@ -0,0 +1,263 @@
+package gohar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Reharmonization is a transformation of the chords of a [Progression].
+type Reharmonization int
+
+const (
+	// TritoneSubstitution replaces dominant seventh chords with the dominant
+	// seventh a tritone away (G7 → D♭7).
+	TritoneSubstitution Reharmonization = iota
+	// SecondaryDominants inserts the dominant seventh of major and minor chords
+	// before them (| F | C | → | F G7 | C |).
+	SecondaryDominants
+	// RelatedIIV inserts the related ii chord before dominant seventh chords
+	// (| G7 | C | → | Dm7 G7 | C |), or the related iiø7 if the dominant resolves
+	// to a minor chord.
+	RelatedIIV
+	// BackdoorDominants replaces dominant seventh chords that resolve to a major
+	// chord with the backdoor dominant (♭VII7), and their related ii with the iv
+	// chord (| Dm7 G7 | C | → | Fm7 B♭7 | C |).
+	BackdoorDominants
+	// DiminishedPassing inserts a diminished seventh chord between chords whose
+	// roots are a whole step apart (| C | Dm7 | → | C C♯dim7 | Dm7 |).
+	DiminishedPassing
+	// ChromaticApproach inserts a chord of the same kind a half step above the
+	// chords that aren't already approached chromatically (| Dm7 | C | → | Dm7
+	// D♭ | C |).
+	ChromaticApproach
+)
+
+var reharmonizationNames = [...]string{
+	TritoneSubstitution: "tritone substitution",
+	SecondaryDominants:  "secondary dominant",
+	RelatedIIV:          "related ii-V",
+	BackdoorDominants:   "backdoor dominant",
+	DiminishedPassing:   "diminished passing chord",
+	ChromaticApproach:   "chromatic approach",
+}
+
+// String returns the name of the reharmonization.
+// "<invalid>" is returned if the reharmonization is invalid.
+func (r Reharmonization) String() string {
+	if r < 0 || int(r) >= len(reharmonizationNames) {
+		return "<invalid>"
+	}
+	return reharmonizationNames[r]
+}
+
+// A ReharmChange reports a change made to a progression by a [Reharmonization].
+type ReharmChange struct {
+	// Reharmonization is the transformation that made the change.
+	Reharmonization Reharmonization
+	// Index is the index of the first changed chord in the new progression.
+	Index int
+	// Before are the chords of the original progression that were changed.
+	Before []ProgressionChord
+	// After are the chords that replace them in the new progression.
+	After []ProgressionChord
+}
+
+// String describes the change, e.g. "tritone substitution: G7 → D♭7".
+func (c ReharmChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.Reharmonization, chordList(c.Before), chordList(c.After))
+}
+
+func chordList(chords []ProgressionChord) string {
+	symbols := make([]string, len(chords))
+	for i, c := range chords {
+		symbols[i] = c.Chord.String()
+	}
+	return strings.Join(symbols, " ")
+}
+
+// Reharmonize applies the reharmonizations to the progression, one after the
+// other, and returns the new progression along with the changes made.
+func Reharmonize(p Progression, reharms ...Reharmonization) (Progression, []ReharmChange) {
+	var changes []ReharmChange
+	for _, r := range reharms {
+		var c []ReharmChange
+		p, c = r.Apply(p)
+		changes = append(changes, c...)
+	}
+	return p, changes
+}
+
+// Apply applies the reharmonization to the progression, and returns the new
+// progression along with the changes made. The total duration of the
+// progression is preserved: chords inserted before a chord take the second
+// half of the previous chord, and chords that last less than two beats are
+// never split.
+func (r Reharmonization) Apply(p Progression) (Progression, []ReharmChange) {
+	rh := reharmonizer{kind: r, chords: make([]ProgressionChord, 0, len(p.Chords))}
+	for i, c := range p.Chords {
+		var next *ProgressionChord
+		if i+1 < len(p.Chords) {
+			next = &p.Chords[i+1]
+		}
+		switch r {
+		case TritoneSubstitution:
+			if isDominantSeventh(c.Pattern) {
+				sub := c
+				sub.Chord = c.Transpose(tritoneAbove(c.Root))
+				rh.replace(c, sub)
+				continue
+			}
+		case SecondaryDominants:
+			if isTonicizable(c.Pattern) {
+				dominant := Chord{Root: c.Root.Transpose(IntPerfectFifth), Pattern: ChordPattern7}
+				if prev, ok := rh.previous(); ok && !isSameRoot(prev.Chord, dominant) && !isSameRoot(prev.Chord, c.Chord) {
+					rh.insert(dominant)
+				}
+			}
+		case RelatedIIV:
+			if isDominantSeventh(c.Pattern) {
+				ii := Chord{Root: c.Root.Transpose(IntPerfectFifth), Pattern: ChordPatternMinor7}
+				if next != nil && next.Pattern.HasDegree(PitchDiffMinorThird) {
+					ii.Pattern = ChordPatternMinor7Flat5
+				}
+				prev, ok := rh.previous()
+				if (!ok || !isSameRoot(prev.Chord, ii)) && c.Beats >= 2 {
+					rh.split(c, ii)
+					continue
+				}
+			}
+		case BackdoorDominants:
+			if next != nil && isDominantSeventh(c.Pattern) && isMajorTonic(next.Pattern) &&
+				c.Root.IsEnharmonic(next.Root.Transpose(IntPerfectFifth)) {
+				backdoor := c
+				backdoor.Chord = Chord{Root: next.Root.Transpose(IntMajorSecond.Down()), Pattern: ChordPattern7}
+				if prev, ok := rh.previous(); ok && prev.Pattern == ChordPatternMinor7 &&
+					prev.Root.IsEnharmonic(c.Root.Transpose(IntPerfectFifth)) {
+					iv := prev
+					iv.Chord = Chord{Root: next.Root.Transpose(IntPerfectFourth), Pattern: ChordPatternMinor7}
+					rh.replaceLast(iv, c, backdoor)
+				} else {
+					rh.replace(c, backdoor)
+				}
+				continue
+			}
+		case DiminishedPassing:
+			if prev, ok := rh.previous(); ok {
+				step := (c.Root.Pitch(0) - prev.Root.Pitch(0)).Normalize()
+				switch step {
+				case PitchDiffMajorSecond:
+					rh.insert(Chord{Root: prev.Root.Transpose(Interval{0, 1}), Pattern: ChordPatternDiminished7})
+				case PitchDiffMinorSeventh:
+					rh.insert(Chord{Root: prev.Root.Transpose(Interval{0, -1}), Pattern: ChordPatternDiminished7})
+				}
+			}
+		case ChromaticApproach:
+			if prev, ok := rh.previous(); ok {
+				step := (prev.Root.Pitch(0) - c.Root.Pitch(0)).Normalize()
+				if step != PitchDiffMinorSecond && step != 11 && step != 0 {
+					rh.insert(Chord{Root: c.Root.Transpose(IntMinorSecond), Pattern: c.Pattern})
+				}
+			}
+		}
+		rh.chords = append(rh.chords, c)
+	}
+	p.Chords = rh.chords
+	return p, rh.changes
+}
+
+// reharmonizer builds a reharmonized progression, chord after chord.
+type reharmonizer struct {
+	kind    Reharmonization
+	chords  []ProgressionChord
+	changes []ReharmChange
+}
+
+// previous returns the last chord of the new progression.
+func (rh *reharmonizer) previous() (ProgressionChord, bool) {
+	if len(rh.chords) == 0 {
+		return ProgressionChord{}, false
+	}
+	return rh.chords[len(rh.chords)-1], true
+}
+
+// insert inserts a chord at the end of the new progression, taking the second
+// half of the previous chord.
+func (rh *reharmonizer) insert(chord Chord) {
+	last := len(rh.chords) - 1
+	if last < 0 || rh.chords[last].Beats < 2 {
+		return
+	}
+	before := rh.chords[last]
+	half := before.Beats / 2
+	rh.chords[last].Beats -= half
+	rh.chords = append(rh.chords, ProgressionChord{chord, half})
+	rh.report(last, []ProgressionChord{before}, rh.chords[last:])
+}
+
+// replace appends a chord that replaces a chord of the original progression.
+func (rh *reharmonizer) replace(before, after ProgressionChord) {
+	rh.chords = append(rh.chords, after)
+	rh.report(len(rh.chords)-1, []ProgressionChord{before}, rh.chords[len(rh.chords)-1:])
+}
+
+// replaceLast replaces the last chord of the new progression, and appends a
+// chord that replaces the current chord of the original progression.
+func (rh *reharmonizer) replaceLast(last, current, after ProgressionChord) {
+	i := len(rh.chords) - 1
+	before := []ProgressionChord{rh.chords[i], current}
+	rh.chords[i] = last
+	rh.chords = append(rh.chords, after)
+	rh.report(i, before, rh.chords[i:])
+}
+
+// split splits a chord of the original progression in two halves, the first
+// half being given to another chord.
+func (rh *reharmonizer) split(c ProgressionChord, first Chord) {
+	half := c.Beats / 2
+	rh.chords = append(rh.chords, ProgressionChord{first, half}, ProgressionChord{c.Chord, c.Beats - half})
+	rh.report(len(rh.chords)-2, []ProgressionChord{c}, rh.chords[len(rh.chords)-2:])
+}
+
+func (rh *reharmonizer) report(index int, before, after []ProgressionChord) {
+	rh.changes = append(rh.changes, ReharmChange{
+		Reharmonization: rh.kind,
+		Index:           index,
+		Before:          before,
+		After:           append([]ProgressionChord(nil), after...),
+	})
+}
+
+func isDominantSeventh(c ChordPattern) bool {
+	return c.HasAllDegrees(PitchDiffMajorThird, PitchDiffMinorSeventh)
+}
+
+// isTonicizable returns true for major and minor chords (without a dominant
+// function), that can be preceded by their own dominant.
+func isTonicizable(c ChordPattern) bool {
+	return c.HasDegree(PitchDiffPerfectFifth) &&
+		c.HasAnyDegree(PitchDiffMajorThird, PitchDiffMinorThird) &&
+		!isDominantSeventh(c)
+}
+
+func isMajorTonic(c ChordPattern) bool {
+	return c.HasDegree(PitchDiffMajorThird) && !c.HasDegree(PitchDiffMinorSeventh)
+}
+
+func isSameRoot(a, b Chord) bool {
+	return a.Root.IsEnharmonic(b.Root)
+}
+
+// tritoneAbove returns the tritone (augmented fourth or diminished fifth) that
+// gives the simplest spelling of the substitute root.
+func tritoneAbove(root PitchClass) Interval {
+	fourth, fifth := root.Transpose(IntAugmentedFourth), root.Transpose(IntDiminishedFifth)
+	if altSize(fourth) < altSize(fifth) {
+		return IntAugmentedFourth
+	}
+	return IntDiminishedFifth
+}
+
+func altSize(pc PitchClass) Pitch {
+	alt := pc.Alt()
+	return max(alt, -alt)
+}
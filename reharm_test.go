@@ -0,0 +1,126 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestReharmonizationString(t *testing.T) {
+	Expect(t,
+		Equal("tritone substitution", TritoneSubstitution.String()),
+		Equal("related ii-V", RelatedIIV.String()),
+		Equal("<invalid>", Reharmonization(-1).String()),
+		Equal("<invalid>", Reharmonization(42).String()),
+	)
+}
+
+func TestReharmonizationApply(t *testing.T) {
+	testCases := []struct {
+		Reharmonization Reharmonization
+		Input           string
+		Want            string
+		Changes         []string
+		Indices         []int
+	}{
+		{
+			TritoneSubstitution,
+			"| Dm7 G7 | Cmaj7 | A7 | D♭7 |",
+			"| Dm7 D♭7 | Cmaj7 | E♭7 | G7 |",
+			[]string{
+				"tritone substitution: G7 → D♭7",
+				"tritone substitution: A7 → E♭7",
+				"tritone substitution: D♭7 → G7",
+			},
+			[]int{1, 3, 4},
+		},
+		{
+			SecondaryDominants,
+			"| C | F | Dm | G7 | C |",
+			"| C | F A7 | Dm | G7 | C |",
+			[]string{"secondary dominant: F → F A7"},
+			[]int{1},
+		},
+		{
+			RelatedIIV,
+			"| G7 | C | E7 | Am |",
+			"| Dm7 G7 | C | Bm7♭5 E7 | Am |",
+			[]string{
+				"related ii-V: G7 → Dm7 G7",
+				"related ii-V: E7 → Bm7♭5 E7",
+			},
+			[]int{0, 3},
+		},
+		{
+			RelatedIIV,
+			"| Dm7 | G7 | C |",
+			"| Dm7 | G7 | C |",
+			nil,
+			nil,
+		},
+		{
+			BackdoorDominants,
+			"| Dm7 G7 | C | G7 | C | G7 | Am |",
+			"| Fm7 B♭7 | C | B♭7 | C | G7 | Am |",
+			[]string{
+				"backdoor dominant: Dm7 G7 → Fm7 B♭7",
+				"backdoor dominant: G7 → B♭7",
+			},
+			[]int{0, 3},
+		},
+		{
+			DiminishedPassing,
+			"| C | Dm7 | Em7 | Dm7 | G7 |",
+			"| C C♯dim7 | Dm7 D♯dim7 | Em7 E♭dim7 | Dm7 | G7 |",
+			[]string{
+				"diminished passing chord: C → C C♯dim7",
+				"diminished passing chord: Dm7 → Dm7 D♯dim7",
+				"diminished passing chord: Em7 → Em7 E♭dim7",
+			},
+			[]int{0, 2, 4},
+		},
+		{
+			ChromaticApproach,
+			"| Dm7 | C | D♭7 | C |",
+			"| Dm7 D♭ | C | D♭7 | C |",
+			[]string{"chromatic approach: Dm7 → Dm7 D♭"},
+			[]int{0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Reharmonization.String()+" "+tc.Input, func(t *testing.T) {
+			p, err := ParseProgression(tc.Input, 4)
+			Require(t, NoError(err))
+			got, changes := tc.Reharmonization.Apply(p)
+			var descriptions []string
+			var indices []int
+			for _, c := range changes {
+				descriptions = append(descriptions, c.String())
+				indices = append(indices, c.Index)
+			}
+			Expect(t,
+				Equal(tc.Want, got.String()),
+				Equal(p.Beats(), got.Beats()),
+				Equal(tc.Changes, descriptions),
+				Equal(tc.Indices, indices),
+			)
+		})
+	}
+}
+
+func TestReharmonize(t *testing.T) {
+	p, err := ParseProgression("| Fmaj7 | Cmaj7 |", 4)
+	Require(t, NoError(err))
+	got, changes := Reharmonize(p, SecondaryDominants, RelatedIIV, TritoneSubstitution)
+	Expect(t,
+		Equal("| Fmaj7 . Dm7 D♭7 | Cmaj7 |", got.String()),
+		SliceHasLength(3, changes),
+	)
+	Expect(t, Equal([]ProgressionChord{
+		{chordFromSymbol(t, "Fmaj7"), 2},
+		{chordFromSymbol(t, "Dm7"), 1},
+		{chordFromSymbol(t, "D♭7"), 1},
+		{chordFromSymbol(t, "Cmaj7"), 4},
+	}, got.Chords))
+}
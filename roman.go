@@ -0,0 +1,238 @@
+package gohar
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Degree returns the (1-based) degree of the pitch class within the scale, along
+// with its alteration relative to the scale: in C major, E is degree 3 with no
+// alteration, while E♭ is degree 3 flattened (alt = -1).
+//
+// Degrees are found by note name, so that the spelling is taken into account:
+// in C major, D♯ is a raised 2nd while E♭ is a lowered 3rd.
+//
+// ErrInvalidDegree is returned if the scale has no note with the same name.
+func (s Scale) Degree(pc PitchClass) (int, Pitch, error) {
+	if !pc.IsValid() {
+		return 0, 0, ErrInvalidPitchClass
+	}
+	for i, spc := range slices.Collect(s.PitchClasses()) {
+		if spc.Base() == pc.Base() {
+			alt := (pc.Pitch(0) - spc.Pitch(0) + 6).Normalize() - 6
+			return i + 1, alt, nil
+		}
+	}
+	return 0, 0, wrapErrorf(ErrInvalidDegree, "%s is not in %s", pc, s)
+}
+
+// pitchClassAt returns the pitch class of the scale at given (1-based) degree.
+func (s Scale) pitchClassAt(degree int) (PitchClass, error) {
+	pcs := slices.Collect(s.PitchClasses())
+	if degree < 1 || degree > len(pcs) {
+		return 0, wrapErrorf(ErrInvalidDegree, "%d", degree)
+	}
+	return pcs[degree-1], nil
+}
+
+var romanNumerals = [...]string{"I", "II", "III", "IV", "V", "VI", "VII"}
+
+// romanStyle names chord qualities the way they are written after roman numerals:
+// minor chords are already expressed by lowercase numerals.
+var romanStyle = ChordStyle{
+	Major:          "maj",
+	Diminished:     "°",
+	HalfDiminished: "ø",
+	Augmented:      "+",
+}
+
+// Augmented sixth chords, relative to the tonic.
+var (
+	italianSixth ChordPattern = 0b000101000001 // 1 ♯4 ♭6
+	frenchSixth  ChordPattern = 0b000101000101 // 1 2 ♯4 ♭6
+	germanSixth  ChordPattern = 0b000101001001 // 1 ♭3 ♯4 ♭6
+)
+
+// RomanNumeral returns the roman numeral analysis of the chord in given key,
+// such as "ii7", "V7/V", "♭VI", "viiø7", "vii°7/ii", "N6", "It+6" or "Fr+6".
+//
+// Uppercase numerals denote chords with a major third, lowercase numerals
+// chords with a minor third. Inversions aren't represented, except for the
+// neapolitan sixth. German augmented sixths are only told apart from the
+// dominant seventh chord on the ♭6 degree ("♭VI7") if they are rooted on the
+// tonic, with the ♭6 in the bass (see [ParseRomanNumeral]).
+//
+// ErrInvalidDegree is returned if the root of the chord has no degree in the key.
+func RomanNumeral(key Scale, chord Chord) (string, error) {
+	tonic := key.Root.Pitch(0)
+	var relative ChordPattern
+	for p := range chord.Pitches(chord.Root.Pitch(0)) {
+		relative = relative.Add((p - tonic).Normalize())
+	}
+	bass := chord.Root
+	if chord.HasBass() {
+		bass = chord.Bass
+	}
+	bassPitch := (bass.Pitch(0) - tonic).Normalize()
+
+	switch {
+	case relative == italianSixth && bassPitch == PitchDiffMinorSixth:
+		return "It+6", nil
+	case relative == frenchSixth && bassPitch == PitchDiffMinorSixth:
+		return "Fr+6", nil
+	case relative == germanSixth && bassPitch == PitchDiffMinorSixth &&
+		chord.Root.Pitch(0) == tonic:
+		return "Ger+6", nil
+	case chord.Pattern == ChordPatternMajor &&
+		(chord.Root.Pitch(0)-tonic).Normalize() == PitchDiffMinorSecond &&
+		bassPitch == PitchDiffPerfectFourth:
+		return "N6", nil
+	}
+
+	if ScalePattern(relative)&^key.Pattern != 0 {
+		if target, ok := secondaryTarget(key, chord); ok {
+			function, err := romanNumeral(Scale{target.Root, ScalePatternMajor}, chord)
+			if err != nil {
+				return "", err
+			}
+			numeral, err := romanNumeral(key, target)
+			if err != nil {
+				return "", err
+			}
+			return function + "/" + numeral, nil
+		}
+	}
+	return romanNumeral(key, chord)
+}
+
+// romanNumeral names the chord after the degree of its root, without looking
+// for any special function.
+func romanNumeral(key Scale, chord Chord) (string, error) {
+	degree, alt, err := key.Degree(chord.Root)
+	if err != nil {
+		return "", err
+	}
+	if degree > len(romanNumerals) {
+		return "", wrapErrorf(ErrInvalidDegree, "%d", degree)
+	}
+	numeral := romanNumerals[degree-1]
+	c := chord.Pattern
+	if c.HasDegree(PitchDiffMinorThird) && !c.HasDegree(PitchDiffMajorThird) {
+		numeral = strings.ToLower(numeral)
+	}
+	return altToString(alt) + numeral + romanStyle.Name(c), nil
+}
+
+// secondaryTarget returns the diatonic triad of the key that the chord
+// tonicizes, if the chord is a secondary dominant (V/x) or a secondary leading
+// tone chord (vii°/x). The tonic itself isn't considered as a target.
+func secondaryTarget(key Scale, chord Chord) (Chord, bool) {
+	c := chord.Pattern
+	var target PitchClass
+	switch {
+	case c.HasAllDegrees(PitchDiffMajorThird, PitchDiffPerfectFifth) &&
+		!c.HasAnyDegree(PitchDiffMinorThird, PitchDiffMajorSeventh):
+		target = chord.Root.Transpose(IntPerfectFourth)
+	case c.HasAllDegrees(PitchDiffMinorThird, PitchDiffDiminishedFifth) &&
+		!c.HasAnyDegree(PitchDiffMajorThird, PitchDiffPerfectFifth):
+		target = chord.Root.Transpose(IntMinorSecond)
+	default:
+		return Chord{}, false
+	}
+	degree, alt, err := key.Degree(target)
+	if err != nil || alt != 0 || degree == 1 {
+		return Chord{}, false
+	}
+	// only major or minor triads can be tonicized
+	triads, err := key.Chords(3)
+	if err != nil || degree > len(triads) {
+		return Chord{}, false
+	}
+	triad := triads[degree-1]
+	if triad.Pattern != ChordPatternMajor && triad.Pattern != ChordPatternMinor {
+		return Chord{}, false
+	}
+	return triad, true
+}
+
+var romanNumeralRegexp = regexp.MustCompile(
+	`^(bb|b|♭|𝄫|##|#|♯|𝄪)?(VII|VI|V|IV|III|II|I|vii|vi|v|iv|iii|ii|i)([^/]*)(?:/(.+))?$`,
+)
+
+// ParseRomanNumeral realizes a roman numeral (e.g. "ii7", "♭VI", "V7/V", "vii°7/ii",
+// "N6", "It+6", "Fr+6" or "Ger+6") into a chord of given key.
+//
+// Uppercase numerals are realized as major chords, lowercase numerals as minor
+// chords. Numerals can be followed by the suffix of a chord symbol, such as "7",
+// "maj7", "°", "°7", "ø7" or "+".
+//
+// Secondary functions (after a "/") are realized within the major key
+// of the targeted degree if it's uppercase, or its harmonic minor key if it's
+// lowercase.
+//
+// ErrInvalidRomanNumeral is returned if the input cannot be parsed.
+func ParseRomanNumeral(input string, key Scale) (Chord, error) {
+	tonic := key.Root
+	switch input {
+	case "N", "N6":
+		root := tonic.Transpose(IntMinorSecond)
+		chord := Chord{Root: root, Pattern: ChordPatternMajor}
+		if input == "N6" {
+			chord.Bass = root.Transpose(IntMajorThird)
+		}
+		return chord, nil
+	case "It+6", "Fr+6", "Ger+6":
+		// Built on ♭VI, the augmented sixth would be spelled as a minor
+		// seventh: the chord is rooted on the tonic instead, as a ♯11 over
+		// the ♭13 in the bass.
+		pattern := ChordPattern(1).Add(PitchDiffAugmentedEleventh).Add(PitchDiffMinorThirteenth)
+		switch input {
+		case "Fr+6":
+			pattern = pattern.Add(PitchDiffMajorNinth)
+		case "Ger+6":
+			pattern = pattern.Add(PitchDiffMinorThird)
+		}
+		return Chord{Root: tonic, Pattern: pattern, Bass: tonic.Transpose(IntMinorSixth)}, nil
+	}
+
+	match := romanNumeralRegexp.FindStringSubmatch(input)
+	if match == nil {
+		return Chord{}, wrapErrorf(ErrInvalidRomanNumeral, "%q", input)
+	}
+	alt, numeral, suffix, secondary := match[1], match[2], match[3], match[4]
+
+	if secondary != "" {
+		target, err := ParseRomanNumeral(secondary, key)
+		if err != nil {
+			return Chord{}, err
+		}
+		targetKey := Scale{target.Root, ScalePatternMajor}
+		if target.Pattern.HasDegree(PitchDiffMinorThird) {
+			targetKey.Pattern = ScalePatternHarmonicMinor
+		}
+		return ParseRomanNumeral(alt+numeral+suffix, targetKey)
+	}
+
+	degree := slices.Index(romanNumerals[:], strings.ToUpper(numeral)) + 1
+	root, err := key.pitchClassAt(degree)
+	if err != nil {
+		return Chord{}, wrapErrorf(ErrInvalidRomanNumeral, "%q: %s", input, err)
+	}
+	if alt != "" {
+		a, err := ParseAlteration(alt)
+		if err != nil {
+			return Chord{}, wrapErrorf(ErrInvalidRomanNumeral, "%q: %s", input, err)
+		}
+		root = root.ClipToPitch(root.Pitch(0) + a)
+	}
+	if numeral != strings.ToUpper(numeral) &&
+		!strings.HasPrefix(suffix, "°") && !strings.HasPrefix(suffix, "ø") {
+		suffix = "m" + suffix
+	}
+	pattern, err := parseChordSuffix(suffix)
+	if err != nil {
+		return Chord{}, wrapErrorf(ErrInvalidRomanNumeral, "%q: %s", input, err)
+	}
+	return Chord{Root: root, Pattern: pattern}, nil
+}
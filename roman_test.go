@@ -0,0 +1,177 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestScaleDegree(t *testing.T) {
+	cMajor := Scale{PitchClassC, ScalePatternMajor}
+	testCases := []struct {
+		Scale
+		PitchClass
+		WantDegree int
+		WantAlt    Pitch
+	}{
+		{cMajor, PitchClassC, 1, 0},
+		{cMajor, PitchClassE, 3, 0},
+		{cMajor, PitchClassE.Flat(), 3, -1},
+		{cMajor, PitchClassD.Sharp(), 2, 1},
+		{cMajor, PitchClassB.Flat(), 7, -1},
+		{cMajor, PitchClassC.Flat(), 1, -1},
+		{Scale{PitchClassE.Flat(), ScalePatternMajor}, PitchClassA, 4, 1},
+		{Scale{PitchClassA, ScalePatternHarmonicMinor}, PitchClassG.Sharp(), 7, 0},
+		{Scale{PitchClassA, ScalePatternHarmonicMinor}, PitchClassG, 7, -1},
+	}
+
+	for _, tc := range testCases {
+		degree, alt, err := tc.Scale.Degree(tc.PitchClass)
+		Expect(t,
+			NoError(err),
+			Equalf(tc.WantDegree, degree, "degree of %s in %s", tc.PitchClass, tc.Scale),
+			Equalf(tc.WantAlt, alt, "alt of %s in %s", tc.PitchClass, tc.Scale),
+		)
+	}
+
+	_, _, err := Scale{PitchClassC, ScalePatternMajorPentatonic}.Degree(PitchClassF)
+	Expect(t, IsError(ErrInvalidDegree, err))
+	_, _, err = cMajor.Degree(0)
+	Expect(t, IsError(ErrInvalidPitchClass, err))
+}
+
+func TestRomanNumeral(t *testing.T) {
+	cMajor := Scale{PitchClassC, ScalePatternMajor}
+	aMinor := Scale{PitchClassA, ScalePatternHarmonicMinor}
+	eFlatMajor := Scale{PitchClassE.Flat(), ScalePatternMajor}
+
+	testCases := []struct {
+		Key   Scale
+		Input string
+		Want  string
+	}{
+		{cMajor, "C", "I"},
+		{cMajor, "Cmaj7", "Imaj7"},
+		{cMajor, "Dm7", "ii7"},
+		{cMajor, "G7", "V7"},
+		{cMajor, "Bm7b5", "viiø7"},
+		{cMajor, "Bdim", "vii°"},
+		{cMajor, "Ab", "♭VI"},
+		{cMajor, "Eb", "♭III"},
+		{cMajor, "Bb7", "♭VII7"},
+		{cMajor, "D7", "V7/V"},
+		{cMajor, "A7", "V7/ii"},
+		{cMajor, "E", "V/vi"},
+		{cMajor, "C7", "V7/IV"},
+		{cMajor, "C#dim7", "vii°7/ii"},
+		{cMajor, "F#dim7", "vii°7/V"},
+		{cMajor, "Db/F", "N6"},
+		{cMajor, "Db", "♭II"},
+		{cMajor, "Ab7", "♭VI7"},
+		{aMinor, "Am", "i"},
+		{aMinor, "E7", "V7"},
+		{aMinor, "G#dim7", "vii°7"},
+		{aMinor, "Caug", "III+"},
+		{aMinor, "Bb/D", "N6"},
+		{eFlatMajor, "Fm7", "ii7"},
+		{eFlatMajor, "C7", "V7/ii"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			chord, err := ParseChord(tc.Input)
+			Require(t, NoError(err))
+			got, err := RomanNumeral(tc.Key, chord)
+			Expect(t, NoError(err), Equal(tc.Want, got))
+		})
+	}
+
+	t.Run("augmented sixths", func(t *testing.T) {
+		italian := Chord{
+			Root:    PitchClassA.Flat(),
+			Pattern: ChordPatternMajor.Omit(PitchDiffPerfectFifth).Add(PitchDiffMinorSeventh),
+		}
+		french := italian
+		french.Pattern = french.Pattern.Add(PitchDiffDiminishedFifth)
+
+		it, err := RomanNumeral(cMajor, italian)
+		Expect(t, NoError(err), Equal("It+6", it))
+		fr, err := RomanNumeral(cMajor, french)
+		Expect(t, NoError(err), Equal("Fr+6", fr))
+
+		for _, numeral := range []string{"It+6", "Fr+6", "Ger+6"} {
+			chord, err := ParseRomanNumeral(numeral, cMajor)
+			Require(t, NoError(err))
+			got, err := RomanNumeral(cMajor, chord)
+			Expect(t, NoError(err), Equal(numeral, got))
+		}
+	})
+
+	_, err := RomanNumeral(Scale{PitchClassC, ScalePatternMajorPentatonic}, Chord{
+		Root:    PitchClassF,
+		Pattern: ChordPatternMajor,
+	})
+	Expect(t, IsError(ErrInvalidDegree, err))
+}
+
+func TestParseRomanNumeral(t *testing.T) {
+	isError := HasError[Chord]
+	isChord := AsCheckFunc(func(want string, got Chord) error {
+		return Equal(want, got.String())
+	})
+	hasPitchClasses := func(want ...string) CheckFunc[Chord] {
+		return func(got Chord, err error) error {
+			if err != nil {
+				return err
+			}
+			var names []string
+			for pc := range got.PitchClasses() {
+				names = append(names, pc.String())
+			}
+			return Equal(want, names)
+		}
+	}
+	cMajor := Scale{PitchClassC, ScalePatternMajor}
+	cMinor := Scale{PitchClassC, ScalePatternHarmonicMinor}
+	eFlatMajor := Scale{PitchClassE.Flat(), ScalePatternMajor}
+
+	testCases := []struct {
+		Key   Scale
+		Input string
+		Check CheckFunc[Chord]
+	}{
+		{cMajor, "", isError(ErrInvalidRomanNumeral)},
+		{cMajor, "VIII", isError(ErrInvalidRomanNumeral)},
+		{cMajor, "Vxyz", isError(ErrInvalidRomanNumeral)},
+		{cMajor, "Ib1", isError(ErrInvalidRomanNumeral)},
+		{cMajor, "I", isChord("C")},
+		{cMajor, "ii7", isChord("Dm7")},
+		{cMajor, "V7", isChord("G7")},
+		{cMajor, "viiø7", isChord("Bm7♭5")},
+		{cMajor, "vii°", isChord("Bdim")},
+		{cMajor, "Imaj7", isChord("Cmaj7")},
+		{cMajor, "♭VI", isChord("A♭")},
+		{cMajor, "bIII", isChord("E♭")},
+		{cMajor, "#iv°7", isChord("F♯dim7")},
+		{cMajor, "V7/V", isChord("D7")},
+		{cMajor, "vii°7/ii", isChord("C♯dim7")},
+		{cMajor, "N6", isChord("D♭/F")},
+		{cMajor, "It+6", hasPitchClasses("A♭", "C", "F♯")},
+		{cMajor, "Fr+6", hasPitchClasses("A♭", "C", "D", "F♯")},
+		{cMajor, "Ger+6", hasPitchClasses("A♭", "C", "E♭", "F♯")},
+		{cMinor, "Ger+6", hasPitchClasses("A♭", "C", "E♭", "F♯")},
+		{eFlatMajor, "It+6", hasPitchClasses("C♭", "E♭", "A")},
+		{cMinor, "i", isChord("Cm")},
+		{cMinor, "V7", isChord("G7")},
+		{cMinor, "III+", isChord("E♭aug")},
+		{eFlatMajor, "V7/ii", isChord("C7")},
+		{eFlatMajor, "V7/V", isChord("F7")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			got, err := ParseRomanNumeral(tc.Input, tc.Key)
+			Expect(t, tc.Check(got, err))
+		})
+	}
+}
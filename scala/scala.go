@@ -0,0 +1,275 @@
+// Package scala reads tunings from the Scala file formats: scales (.scl) and
+// keyboard mappings (.kbm).
+//
+// See https://www.huygens-fokker.org/scala/scl_format.html for a description of
+// these formats.
+package scala
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ArnaudCalmettes/gohar"
+)
+
+var (
+	ErrInvalidScale   = errors.New("invalid scala scale")
+	ErrInvalidMapping = errors.New("invalid scala keyboard mapping")
+)
+
+// A Scale is a scale read from a .scl file.
+type Scale struct {
+	// Description is the one-line description of the scale.
+	Description string
+	// Cents are the pitches of the degrees of the scale, in cents above the
+	// first degree (that is implicit, and not listed). The last pitch is the
+	// period of the scale, usually an octave (1200 cents).
+	Cents []float64
+}
+
+// Degrees returns the number of degrees of the scale within a period.
+func (s Scale) Degrees() int {
+	return len(s.Cents)
+}
+
+// Period returns the interval, in cents, after which the scale repeats.
+func (s Scale) Period() float64 {
+	if len(s.Cents) == 0 {
+		return 1200
+	}
+	return s.Cents[len(s.Cents)-1]
+}
+
+// DegreeCents returns the pitch of given degree, in cents above the first
+// degree. Degrees beyond the period (or below the first degree) are repeated
+// every period.
+func (s Scale) DegreeCents(degree int) float64 {
+	n := s.Degrees()
+	if n == 0 {
+		return 0
+	}
+	periods, d := floorDiv(degree, n)
+	cents := float64(periods) * s.Period()
+	if d > 0 {
+		cents += s.Cents[d-1]
+	}
+	return cents
+}
+
+// ParseScale reads a scale in the .scl format.
+//
+// ErrInvalidScale is returned if the scale is malformed.
+func ParseScale(r io.Reader) (Scale, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return Scale{}, err
+	}
+	if len(lines) < 2 {
+		return Scale{}, fmt.Errorf("%w: missing header", ErrInvalidScale)
+	}
+	s := Scale{Description: strings.TrimSpace(lines[0])}
+	count, err := strconv.Atoi(firstField(lines[1]))
+	if err != nil || count < 0 {
+		return Scale{}, fmt.Errorf("%w: invalid number of notes %q", ErrInvalidScale, lines[1])
+	}
+	if len(lines)-2 < count {
+		return Scale{}, fmt.Errorf("%w: expected %d notes, got %d", ErrInvalidScale, count, len(lines)-2)
+	}
+	s.Cents = make([]float64, count)
+	for i, line := range lines[2 : 2+count] {
+		if s.Cents[i], err = parsePitch(firstField(line)); err != nil {
+			return Scale{}, fmt.Errorf("%w: note %d: %s", ErrInvalidScale, i+1, err)
+		}
+	}
+	return s, nil
+}
+
+// parsePitch parses a pitch value, in cents if it contains a period, or as a
+// ratio otherwise (e.g. "3/2", or "2").
+func parsePitch(value string) (float64, error) {
+	if strings.Contains(value, ".") {
+		return strconv.ParseFloat(value, 64)
+	}
+	num, den, found := strings.Cut(value, "/")
+	n, err := strconv.ParseUint(num, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	d := uint64(1)
+	if found {
+		if d, err = strconv.ParseUint(den, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	if n == 0 || d == 0 {
+		return 0, fmt.Errorf("invalid ratio %q", value)
+	}
+	return 1200 * math.Log2(float64(n)/float64(d)), nil
+}
+
+// A KeyboardMapping maps MIDI keys to the degrees of a scale. It is read from
+// a .kbm file.
+type KeyboardMapping struct {
+	// FirstKey and LastKey are the range of MIDI keys to retune.
+	FirstKey, LastKey int
+	// MiddleKey is the MIDI key where the first degree of the mapping is.
+	MiddleKey int
+	// ReferenceKey is the MIDI key whose frequency is given.
+	ReferenceKey int
+	// ReferenceFrequency is the frequency of the reference key, in Hz.
+	ReferenceFrequency float64
+	// OctaveDegree is the degree of the scale after which the mapping repeats.
+	OctaveDegree int
+	// Degrees are the degrees of the scale of successive keys, starting at
+	// the middle key. Unmapped keys are marked with -1. If empty, the keys are
+	// mapped linearly to the degrees of the scale.
+	Degrees []int
+}
+
+// DefaultMapping maps the MIDI keys linearly to the degrees of a scale, with
+// its first degree at middle C (MIDI key 60), tuned as in 12-TET with A = 440 Hz.
+var DefaultMapping = KeyboardMapping{
+	FirstKey:           0,
+	LastKey:            127,
+	MiddleKey:          60,
+	ReferenceKey:       60,
+	ReferenceFrequency: gohar.PitchC.Frequency(gohar.StandardTuning),
+}
+
+// ParseKeyboardMapping reads a keyboard mapping in the .kbm format.
+//
+// ErrInvalidMapping is returned if the mapping is malformed.
+func ParseKeyboardMapping(r io.Reader) (KeyboardMapping, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return KeyboardMapping{}, err
+	}
+	if len(lines) < 7 {
+		return KeyboardMapping{}, fmt.Errorf("%w: missing header", ErrInvalidMapping)
+	}
+	var (
+		m    KeyboardMapping
+		size int
+	)
+	for i, field := range []*int{&size, &m.FirstKey, &m.LastKey, &m.MiddleKey, &m.ReferenceKey} {
+		if *field, err = strconv.Atoi(firstField(lines[i])); err != nil {
+			return KeyboardMapping{}, fmt.Errorf("%w: line %d: %s", ErrInvalidMapping, i+1, err)
+		}
+	}
+	if m.ReferenceFrequency, err = strconv.ParseFloat(firstField(lines[5]), 64); err != nil {
+		return KeyboardMapping{}, fmt.Errorf("%w: reference frequency: %s", ErrInvalidMapping, err)
+	}
+	if m.OctaveDegree, err = strconv.Atoi(firstField(lines[6])); err != nil {
+		return KeyboardMapping{}, fmt.Errorf("%w: octave degree: %s", ErrInvalidMapping, err)
+	}
+	if size < 0 {
+		return KeyboardMapping{}, fmt.Errorf("%w: invalid size %d", ErrInvalidMapping, size)
+	}
+	// trailing unmapped keys may be omitted
+	m.Degrees = make([]int, size)
+	for i := range m.Degrees {
+		m.Degrees[i] = -1
+		if 7+i >= len(lines) {
+			continue
+		}
+		value := firstField(lines[7+i])
+		if value == "x" || value == "X" {
+			continue
+		}
+		if m.Degrees[i], err = strconv.Atoi(value); err != nil {
+			return KeyboardMapping{}, fmt.Errorf("%w: key %d: %s", ErrInvalidMapping, i, err)
+		}
+	}
+	return m, nil
+}
+
+// degree returns the degree of the scale mapped to given MIDI key, regardless
+// of the range of the mapping.
+func (m KeyboardMapping) degree(key, scaleDegrees int) (int, bool) {
+	if len(m.Degrees) == 0 {
+		return key - m.MiddleKey, true
+	}
+	periods, i := floorDiv(key-m.MiddleKey, len(m.Degrees))
+	if m.Degrees[i] < 0 {
+		return 0, false
+	}
+	octave := m.OctaveDegree
+	if octave == 0 {
+		octave = scaleDegrees
+	}
+	return m.Degrees[i] + periods*octave, true
+}
+
+// A Tuning maps notes to frequencies after a scale and a keyboard mapping.
+// Notes are mapped to MIDI keys after their pitch, middle C being key 60, so
+// that enharmonic notes have the same frequency.
+type Tuning struct {
+	Scale   Scale
+	Mapping KeyboardMapping
+}
+
+// NewTuning returns the tuning of the scale with the default keyboard mapping.
+func NewTuning(s Scale) Tuning {
+	return Tuning{Scale: s, Mapping: DefaultMapping}
+}
+
+// Frequency returns the frequency of the note, in Hz, or 0 if the note is out
+// of the range of the keyboard mapping, or mapped to no degree.
+func (t Tuning) Frequency(n gohar.Note) float64 {
+	return t.KeyFrequency(60 + int(n.Pitch()))
+}
+
+// KeyFrequency returns the frequency of the MIDI key, in Hz, or 0 if the key
+// is out of the range of the keyboard mapping, or mapped to no degree.
+func (t Tuning) KeyFrequency(key int) float64 {
+	if key < t.Mapping.FirstKey || key > t.Mapping.LastKey {
+		return 0
+	}
+	degree, ok := t.Mapping.degree(key, t.Scale.Degrees())
+	if !ok {
+		return 0
+	}
+	refDegree, ok := t.Mapping.degree(t.Mapping.ReferenceKey, t.Scale.Degrees())
+	if !ok {
+		return 0
+	}
+	cents := t.Scale.DegreeCents(degree) - t.Scale.DegreeCents(refDegree)
+	return t.Mapping.ReferenceFrequency * math.Exp2(cents/1200)
+}
+
+// readLines returns the lines of the input, without comments (lines starting
+// with "!").
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "!") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+func firstField(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// floorDiv returns the quotient and the (positive) remainder of a / b.
+func floorDiv(a, b int) (int, int) {
+	q, r := a/b, a%b
+	if r < 0 {
+		q, r = q-1, r+b
+	}
+	return q, r
+}
@@ -0,0 +1,254 @@
+package scala
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ArnaudCalmettes/gohar"
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+// round rounds the values to a hundredth, so that they can be compared.
+func round(values ...float64) []float64 {
+	rounded := make([]float64, len(values))
+	for i, v := range values {
+		rounded[i] = math.Round(v*100) / 100
+	}
+	return rounded
+}
+
+func TestParseScale(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Input       string
+		Description string
+		Cents       []float64
+	}{
+		{
+			"cents",
+			"12-TET\n12\n" +
+				"100.0\n200.\n300.0\n400.0\n500.0\n600.0\n700.0\n800.0\n900.0\n1000.0\n1100.0\n2/1\n",
+			"12-TET",
+			[]float64{100, 200, 300, 400, 500, 600, 700, 800, 900, 1000, 1100, 1200},
+		},
+		{
+			"ratios with comments",
+			"! pentatonic.scl\n!\n Just pentatonic \r\n 5\n!\n9/8\n5/4 major third\n3/2\n5/3\n2\n",
+			"Just pentatonic",
+			[]float64{203.91, 386.31, 701.96, 884.36, 1200},
+		},
+		{
+			"extra lines",
+			"Fifths\n2\n701.955 fifth\n1200.0\n\n! trailing comment\n",
+			"Fifths",
+			[]float64{701.96, 1200},
+		},
+		{
+			"empty",
+			"\n0\n",
+			"",
+			[]float64{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s, err := ParseScale(strings.NewReader(tc.Input))
+			Require(t, NoError(err))
+			Expect(t,
+				Equal(tc.Description, s.Description),
+				Equal(tc.Cents, round(s.Cents...)),
+			)
+		})
+	}
+}
+
+func TestParseScaleInvalid(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+	}{
+		{"empty", ""},
+		{"missing count", "! only a comment\nDescription\n"},
+		{"invalid count", "Description\nfive\n"},
+		{"negative count", "Description\n-1\n"},
+		{"missing notes", "Description\n3\n100.0\n200.0\n"},
+		{"invalid cents", "Description\n1\n1O0.0\n"},
+		{"invalid numerator", "Description\n1\n-3/2\n"},
+		{"invalid denominator", "Description\n1\n3/two\n"},
+		{"null ratio", "Description\n1\n0/1\n"},
+		{"null denominator", "Description\n1\n3/0\n"},
+		{"empty note", "Description\n1\n\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ParseScale(strings.NewReader(tc.Input))
+			Expect(t, IsError(ErrInvalidScale, err))
+		})
+	}
+}
+
+func TestScaleDegreeCents(t *testing.T) {
+	s := Scale{Cents: []float64{200, 400, 700, 900, 1200}}
+	testCases := []struct {
+		Degree int
+		Want   float64
+	}{
+		{0, 0},
+		{2, 400},
+		{5, 1200},
+		{7, 1600},
+		{-1, -300},
+		{-5, -1200},
+		{-6, -1500},
+	}
+
+	for _, tc := range testCases {
+		Expect(t, Equalf(tc.Want, s.DegreeCents(tc.Degree), "degree %d", tc.Degree))
+	}
+	Expect(t,
+		Equal(1200.0, Scale{}.Period()),
+		Equal(0.0, Scale{}.DegreeCents(3)),
+	)
+}
+
+func TestParseKeyboardMapping(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+		Want  KeyboardMapping
+	}{
+		{
+			"linear",
+			"! linear.kbm\n0\n0\n127\n60\n69\n440.0\n0\n",
+			KeyboardMapping{
+				LastKey:            127,
+				MiddleKey:          60,
+				ReferenceKey:       69,
+				ReferenceFrequency: 440,
+				Degrees:            []int{},
+			},
+		},
+		{
+			"white keys",
+			"12 size\n21\n108\n60\n62 D\n293.66\n7\n" +
+				"0\nx\n1\nx\n2\n3\nX\n4\nx\n5\nx\n6\n",
+			KeyboardMapping{
+				FirstKey:           21,
+				LastKey:            108,
+				MiddleKey:          60,
+				ReferenceKey:       62,
+				ReferenceFrequency: 293.66,
+				OctaveDegree:       7,
+				Degrees:            []int{0, -1, 1, -1, 2, 3, -1, 4, -1, 5, -1, 6},
+			},
+		},
+		{
+			"omitted trailing keys",
+			"4\n0\n127\n60\n60\n261.63\n3\n0\n1\n",
+			KeyboardMapping{
+				LastKey:            127,
+				MiddleKey:          60,
+				ReferenceKey:       60,
+				ReferenceFrequency: 261.63,
+				OctaveDegree:       3,
+				Degrees:            []int{0, 1, -1, -1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			m, err := ParseKeyboardMapping(strings.NewReader(tc.Input))
+			Expect(t,
+				NoError(err),
+				Equal(tc.Want, m),
+			)
+		})
+	}
+}
+
+func TestParseKeyboardMappingInvalid(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+	}{
+		{"empty", ""},
+		{"missing header", "0\n0\n127\n60\n69\n440.0\n"},
+		{"invalid size", "twelve\n0\n127\n60\n69\n440.0\n0\n"},
+		{"invalid first key", "0\nfirst\n127\n60\n69\n440.0\n0\n"},
+		{"invalid reference key", "0\n0\n127\n60\n\n440.0\n0\n"},
+		{"invalid reference frequency", "0\n0\n127\n60\n69\nA4\n0\n"},
+		{"invalid octave degree", "0\n0\n127\n60\n69\n440.0\n1.5\n"},
+		{"negative size", "-1\n0\n127\n60\n69\n440.0\n0\n"},
+		{"invalid key", "2\n0\n127\n60\n69\n440.0\n0\n0\ny\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ParseKeyboardMapping(strings.NewReader(tc.Input))
+			Expect(t, IsError(ErrInvalidMapping, err))
+		})
+	}
+}
+
+func TestTuningFrequency(t *testing.T) {
+	tet, err := ParseScale(strings.NewReader("12-TET\n12\n" +
+		"100.0\n200.0\n300.0\n400.0\n500.0\n600.0\n700.0\n800.0\n900.0\n1000.0\n1100.0\n2/1\n"))
+	Require(t, NoError(err))
+	pentatonic, err := ParseScale(strings.NewReader("Just pentatonic\n5\n9/8\n5/4\n3/2\n5/3\n2/1\n"))
+	Require(t, NoError(err))
+	whiteKeys := KeyboardMapping{
+		FirstKey:           48,
+		LastKey:            72,
+		MiddleKey:          60,
+		ReferenceKey:       69,
+		ReferenceFrequency: 440,
+		OctaveDegree:       5,
+		Degrees:            []int{0, -1, 1, -1, 2, -1, -1, 3, -1, 4, -1, -1},
+	}
+
+	testCases := []struct {
+		Name   string
+		Tuning Tuning
+		Notes  []gohar.Note
+		Want   []float64
+	}{
+		{
+			"12-TET",
+			NewTuning(tet),
+			[]gohar.Note{gohar.NoteC, gohar.NoteA, gohar.NoteB.Sharp(), gohar.NoteA.Octave(-1)},
+			[]float64{261.63, 440, 523.25, 220},
+		},
+		{
+			"linear pentatonic",
+			NewTuning(pentatonic),
+			[]gohar.Note{gohar.NoteC, gohar.NoteC.Sharp(), gohar.NoteD, gohar.NoteF, gohar.NoteG.Octave(-1)},
+			[]float64{261.63, 294.33, 327.03, 523.25, 130.81},
+		},
+		{
+			"mapped pentatonic",
+			Tuning{Scale: pentatonic, Mapping: whiteKeys},
+			[]gohar.Note{gohar.NoteA, gohar.NoteC, gohar.NoteG, gohar.NoteC.Octave(1), gohar.NoteD.Octave(-1)},
+			[]float64{440, 264, 396, 528, 148.5},
+		},
+		{
+			"unmapped and out of range keys",
+			Tuning{Scale: pentatonic, Mapping: whiteKeys},
+			[]gohar.Note{gohar.NoteC.Sharp(), gohar.NoteF, gohar.NoteD.Octave(1), gohar.NoteB.Octave(-2)},
+			[]float64{0, 0, 0, 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var frequencies []float64
+			for _, n := range tc.Notes {
+				frequencies = append(frequencies, tc.Tuning.Frequency(n))
+			}
+			Expect(t, Equal(tc.Want, round(frequencies...)))
+		})
+	}
+}
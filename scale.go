@@ -2,6 +2,8 @@ package gohar
 
 import (
 	"fmt"
+	"iter"
+	"slices"
 )
 
 // A Scale is the association of a root Note and a ScalePattern.
@@ -22,3 +24,60 @@ type Scale struct {
 func (s Scale) String() string {
 	return fmt.Sprintf("Scale(%s:%012b)", s.Root, s.Pattern)
 }
+
+// PitchClasses iterates over the pitch classes of the scale, spelled after the
+// degrees of its pattern (see [ScalePattern.Degrees]).
+func (s Scale) PitchClasses() iter.Seq[PitchClass] {
+	return s.Pattern.PitchClassesWithDegrees(s.Root, s.Pattern.Degrees())
+}
+
+// Harmonize returns the chord built on each degree of the scale, by stacking
+// given number of notes taken every stackBy.ScaleDiff steps of the scale.
+// For instance, stacking 4 notes by thirds yields the diatonic seventh chords,
+// while stacking 3 notes by fourths yields quartal triads.
+//
+// Only the ScaleDiff of stackBy is used, so that the chords remain diatonic.
+// Chord tones beyond two octaves are brought back an octave down.
+//
+// ErrInvalidInterval is returned if stackBy doesn't go up the scale,
+// and ErrInvalidDegree if notes < 1.
+func (s Scale) Harmonize(stackBy Interval, notes int) ([]Chord, error) {
+	if stackBy.ScaleDiff < 1 {
+		return nil, wrapErrorf(ErrInvalidInterval, "cannot stack by %s", stackBy)
+	}
+	if notes < 1 {
+		return nil, wrapErrorf(ErrInvalidDegree, "cannot stack %d notes", notes)
+	}
+	roots := slices.Collect(s.PitchClasses())
+	if len(roots) == 0 {
+		roots = slices.Collect(s.Pattern.PitchClasses(s.Root))
+	}
+	chords := make([]Chord, 0, len(roots))
+	for degree, root := range roots {
+		mode, err := s.Pattern.Mode(degree + 1)
+		if err != nil {
+			return nil, err
+		}
+		pitches := slices.Collect(mode.Pitches(0))
+		var pattern ChordPattern
+		for n := range notes {
+			step := n * int(stackBy.ScaleDiff)
+			p := pitches[step%len(pitches)] + Pitch(step/len(pitches))*PitchDiffOctave
+			for p >= 2*PitchDiffOctave {
+				p -= PitchDiffOctave
+			}
+			pattern = pattern.Add(p)
+		}
+		chords = append(chords, Chord{Root: root, Pattern: pattern})
+	}
+	return chords, nil
+}
+
+// Chords returns the chords built by stacking thirds on each degree of the
+// scale: triads (size 3), seventh chords (size 4), ninth chords (size 5),
+// eleventh chords (size 6) or thirteenth chords (size 7).
+//
+// ErrInvalidDegree is returned if size < 1.
+func (s Scale) Chords(size int) ([]Chord, error) {
+	return s.Harmonize(IntMajorThird, size)
+}
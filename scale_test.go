@@ -21,3 +21,67 @@ func TestScaleStringer(t *testing.T) {
 		)
 	}
 }
+
+func TestScaleHarmonize(t *testing.T) {
+	chordNames := func(chords []Chord) []string {
+		names := make([]string, len(chords))
+		for i, c := range chords {
+			names[i] = c.String()
+		}
+		return names
+	}
+	testCases := []struct {
+		Name string
+		Scale
+		StackBy Interval
+		Notes   int
+		Want    []string
+	}{
+		{
+			"major triads", Scale{PitchClassC, ScalePatternMajor}, IntMajorThird, 3,
+			[]string{"C", "Dm", "Em", "F", "G", "Am", "Bdim"},
+		},
+		{
+			"major sevenths", Scale{PitchClassC, ScalePatternMajor}, IntMinorThird, 4,
+			[]string{"Cmaj7", "Dm7", "Em7", "Fmaj7", "G7", "Am7", "Bm7♭5"},
+		},
+		{
+			"harmonic minor sevenths", Scale{PitchClassA, ScalePatternHarmonicMinor}, IntMinorThird, 4,
+			[]string{"Ammaj7", "Bm7♭5", "Cmaj7♯5", "Dm7", "E7", "Fmaj7", "G♯dim7"},
+		},
+		{
+			"melodic minor sevenths", Scale{PitchClassC, ScalePatternMelodicMinor}, IntMajorThird, 4,
+			[]string{"Cmmaj7", "Dm7", "E♭maj7♯5", "F7", "G7", "Am7♭5", "Bm7♭5"},
+		},
+		{
+			"pentatonic", Scale{PitchClassC, ScalePatternMajorPentatonic}, IntMajorThird, 3,
+			[]string{"C6", "D7sus4", "E7sus4", "G6sus4", "A7sus4"},
+		},
+		{
+			"quartal", Scale{PitchClassD, ScalePatternDorian}, IntPerfectFourth, 3,
+			[]string{"D7sus4", "E7sus4", "Fmaj7♯11 no3", "G7sus4", "A7sus4", "B7sus4", "Cmaj7sus4"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			chords, err := tc.Scale.Harmonize(tc.StackBy, tc.Notes)
+			Expect(t, NoError(err), Equal(tc.Want, chordNames(chords)))
+		})
+	}
+
+	_, err := Scale{PitchClassC, ScalePatternMajor}.Harmonize(IntUnisson, 3)
+	Expect(t, IsError(ErrInvalidInterval, err))
+	_, err = Scale{PitchClassC, ScalePatternMajor}.Chords(0)
+	Expect(t, IsError(ErrInvalidDegree, err))
+}
+
+func TestScaleChords(t *testing.T) {
+	chords, err := Scale{PitchClassG, ScalePatternMixolydian}.Chords(7)
+	Require(t, NoError(err))
+	Expect(t,
+		SliceHasLength(7, chords),
+		Equal(Chord{Root: PitchClassG, Pattern: ChordPattern7.Add(PitchDiffMajorNinth).
+			Add(PitchDiffPerfectEleventh).Add(PitchDiffMajorThirteenth)}, chords[0]),
+	)
+}
@@ -0,0 +1,222 @@
+package gohar
+
+import (
+	"iter"
+	"slices"
+)
+
+// Modes of the major scale.
+const (
+	ScalePatternDorian       ScalePattern = 0b011010101101 // C D Eb F G A Bb
+	ScalePatternPhrygian     ScalePattern = 0b010110101011 // C Db Eb F G Ab Bb
+	ScalePatternLydian       ScalePattern = 0b101011010101 // C D E F# G A B
+	ScalePatternMixolydian   ScalePattern = 0b011010110101 // C D E F G A Bb
+	ScalePatternNaturalMinor ScalePattern = 0b010110101101 // C D Eb F G Ab Bb
+	ScalePatternLocrian      ScalePattern = 0b010101101011 // C Db Eb F Gb Ab Bb
+
+	ScalePatternIonian  = ScalePatternMajor
+	ScalePatternAeolian = ScalePatternNaturalMinor
+)
+
+// Modes of the melodic minor scale.
+const (
+	ScalePatternDorianFlat2     ScalePattern = 0b011010101011 // C Db Eb F G A Bb
+	ScalePatternLydianAugmented ScalePattern = 0b101101010101 // C D E F# G# A B
+	ScalePatternLydianDominant  ScalePattern = 0b011011010101 // C D E F# G A Bb
+	ScalePatternMixolydianFlat6 ScalePattern = 0b010110110101 // C D E F G Ab Bb
+	ScalePatternLocrianNatural2 ScalePattern = 0b010101101101 // C D Eb F Gb Ab Bb
+	ScalePatternAltered         ScalePattern = 0b010101011011 // C Db Eb Fb Gb Ab Bb
+
+	ScalePatternSuperLocrian = ScalePatternAltered
+)
+
+// Modes of the harmonic minor scale.
+const (
+	ScalePatternLocrianNatural6   ScalePattern = 0b011001101011 // C Db Eb F Gb A Bb
+	ScalePatternIonianAugmented   ScalePattern = 0b101100110101 // C D E F G# A B
+	ScalePatternDorianSharp4      ScalePattern = 0b011011001101 // C D Eb F# G A Bb
+	ScalePatternPhrygianDominant  ScalePattern = 0b010110110011 // C Db E F G Ab Bb
+	ScalePatternLydianSharp2      ScalePattern = 0b101011011001 // C D# E F# G A B
+	ScalePatternAlteredDiminished ScalePattern = 0b001101011011 // C Db Eb Fb Gb Ab Bbb
+)
+
+// Modes of the harmonic major scale.
+const (
+	ScalePatternDorianFlat5           ScalePattern = 0b011001101101 // C D Eb F Gb A Bb
+	ScalePatternPhrygianFlat4         ScalePattern = 0b010110011011 // C Db Eb Fb G Ab Bb
+	ScalePatternLydianFlat3           ScalePattern = 0b101011001101 // C D Eb F# G A B
+	ScalePatternMixolydianFlat2       ScalePattern = 0b011010110011 // C Db E F G A Bb
+	ScalePatternLydianAugmentedSharp2 ScalePattern = 0b101101011001 // C D# E F# G# A B
+	ScalePatternLocrianDiminished7    ScalePattern = 0b001101101011 // C Db Eb F Gb Ab Bbb
+)
+
+// Pentatonic, hexatonic and octatonic scales.
+const (
+	ScalePatternMajorPentatonic     ScalePattern = 0b001010010101 // C D E G A
+	ScalePatternMinorPentatonic     ScalePattern = 0b010010101001 // C Eb F G Bb
+	ScalePatternBlues               ScalePattern = 0b010011101001 // C Eb F Gb G Bb
+	ScalePatternMajorBlues          ScalePattern = 0b001010011101 // C D Eb E G A
+	ScalePatternBebopDominant       ScalePattern = 0b111010110101 // C D E F G A Bb B
+	ScalePatternBebopMajor          ScalePattern = 0b101110110101 // C D E F G G# A B
+	ScalePatternBebopDorian         ScalePattern = 0b011010111101 // C D Eb E F G A Bb
+	ScalePatternWholeTone           ScalePattern = 0b010101010101 // C D E F# G# Bb
+	ScalePatternDiminishedHalfWhole ScalePattern = 0b011011011011 // C Db D# E F# G A Bb
+	ScalePatternDiminishedWholeHalf ScalePattern = 0b101101101101 // C D Eb F Gb Ab A B
+	ScalePatternAugmented           ScalePattern = 0b100110011001 // C Eb E G Ab B
+)
+
+// Messiaen's modes of limited transposition.
+const (
+	ScalePatternMessiaen1 = ScalePatternWholeTone
+	ScalePatternMessiaen2 = ScalePatternDiminishedHalfWhole
+
+	ScalePatternMessiaen3 ScalePattern = 0b110111011101 // C D Eb E F# G Ab Bb B
+	ScalePatternMessiaen4 ScalePattern = 0b100111100111 // C Db D F F# G Ab B
+	ScalePatternMessiaen5 ScalePattern = 0b100011100011 // C Db F F# G B
+	ScalePatternMessiaen6 ScalePattern = 0b110101110101 // C D E F F# Ab Bb B
+	ScalePatternMessiaen7 ScalePattern = 0b101111101111 // C Db D Eb F F# G Ab A B
+)
+
+// scaleCatalog lists the known scale patterns, in the order in which they are
+// yielded by [ScalePatterns].
+var scaleCatalog = []ScalePattern{
+	ScalePatternMajor,
+	ScalePatternDorian,
+	ScalePatternPhrygian,
+	ScalePatternLydian,
+	ScalePatternMixolydian,
+	ScalePatternNaturalMinor,
+	ScalePatternLocrian,
+	ScalePatternMelodicMinor,
+	ScalePatternDorianFlat2,
+	ScalePatternLydianAugmented,
+	ScalePatternLydianDominant,
+	ScalePatternMixolydianFlat6,
+	ScalePatternLocrianNatural2,
+	ScalePatternAltered,
+	ScalePatternHarmonicMinor,
+	ScalePatternLocrianNatural6,
+	ScalePatternIonianAugmented,
+	ScalePatternDorianSharp4,
+	ScalePatternPhrygianDominant,
+	ScalePatternLydianSharp2,
+	ScalePatternAlteredDiminished,
+	ScalePatternHarmonicMajor,
+	ScalePatternDorianFlat5,
+	ScalePatternPhrygianFlat4,
+	ScalePatternLydianFlat3,
+	ScalePatternMixolydianFlat2,
+	ScalePatternLydianAugmentedSharp2,
+	ScalePatternLocrianDiminished7,
+	ScalePatternDoubleHarmonicMajor,
+	ScalePatternMajorPentatonic,
+	ScalePatternMinorPentatonic,
+	ScalePatternBlues,
+	ScalePatternMajorBlues,
+	ScalePatternBebopDominant,
+	ScalePatternBebopMajor,
+	ScalePatternBebopDorian,
+	ScalePatternWholeTone,
+	ScalePatternDiminishedHalfWhole,
+	ScalePatternDiminishedWholeHalf,
+	ScalePatternAugmented,
+	ScalePatternMessiaen3,
+	ScalePatternMessiaen4,
+	ScalePatternMessiaen5,
+	ScalePatternMessiaen6,
+	ScalePatternMessiaen7,
+}
+
+// scaleDegrees holds the degrees of the scale patterns that aren't heptatonic.
+// See [ScalePattern.IntervalsWithDegrees].
+var scaleDegrees = map[ScalePattern][]int8{
+	ScalePatternMajorPentatonic:     {1, 2, 3, 5, 6},
+	ScalePatternMinorPentatonic:     {1, 3, 4, 5, 7},
+	ScalePatternBlues:               {1, 3, 4, 5, 5, 7},
+	ScalePatternMajorBlues:          {1, 2, 3, 3, 5, 6},
+	ScalePatternBebopDominant:       {1, 2, 3, 4, 5, 6, 7, 7},
+	ScalePatternBebopMajor:          {1, 2, 3, 4, 5, 5, 6, 7},
+	ScalePatternBebopDorian:         {1, 2, 3, 3, 4, 5, 6, 7},
+	ScalePatternWholeTone:           {1, 2, 3, 4, 5, 7},
+	ScalePatternDiminishedHalfWhole: {1, 2, 2, 3, 4, 5, 6, 7},
+	ScalePatternDiminishedWholeHalf: {1, 2, 3, 4, 5, 6, 6, 7},
+	ScalePatternAugmented:           {1, 3, 3, 5, 6, 7},
+	ScalePatternMessiaen3:           {1, 2, 3, 3, 4, 5, 6, 7, 7},
+	ScalePatternMessiaen4:           {1, 2, 2, 4, 4, 5, 6, 7},
+	ScalePatternMessiaen5:           {1, 2, 4, 4, 5, 7},
+	ScalePatternMessiaen6:           {1, 2, 3, 4, 4, 6, 7, 7},
+	ScalePatternMessiaen7:           {1, 2, 2, 3, 4, 4, 5, 6, 6, 7},
+}
+
+// ScalePatterns iterates over the known scale patterns, including the ones
+// added with [RegisterScalePattern].
+func ScalePatterns() iter.Seq[ScalePattern] {
+	return slices.Values(scaleCatalog)
+}
+
+// Degrees returns the degrees that should be used to spell the scale pattern
+// with [ScalePattern.IntervalsWithDegrees].
+//
+// nil is returned for unknown and heptatonic scale patterns, which are assumed
+// to be stepwise.
+func (s ScalePattern) Degrees() []int8 {
+	return scaleDegrees[s]
+}
+
+// RegisterScalePattern adds a scale pattern to the catalog, along with its
+// degrees (see [ScalePattern.IntervalsWithDegrees]) and its names in the given
+// locales. Registering an already known pattern updates its degrees and names.
+//
+// degrees may be nil for heptatonic scales, that are assumed to be stepwise.
+//
+// This function isn't safe for concurrent use: it is meant to be called at
+// initialization time.
+//
+// ErrInvalidScalePattern is returned if the pattern doesn't contain its root, doesn't
+// fit within an octave, or doesn't match the degrees.
+func RegisterScalePattern(pattern ScalePattern, degrees []int8, names map[*Locale]string) error {
+	if err := checkScalePattern(pattern, degrees); err != nil {
+		return err
+	}
+	if !slices.Contains(scaleCatalog, pattern) {
+		scaleCatalog = append(scaleCatalog, pattern)
+	}
+	if degrees == nil {
+		delete(scaleDegrees, pattern)
+	} else {
+		scaleDegrees[pattern] = slices.Clone(degrees)
+	}
+	for loc, name := range names {
+		if loc.ScaleNames == nil {
+			loc.ScaleNames = make(map[ScalePattern]string)
+		}
+		loc.ScaleNames[pattern] = name
+	}
+	return nil
+}
+
+func checkScalePattern(pattern ScalePattern, degrees []int8) error {
+	if pattern&1 == 0 || pattern >= 1<<12 {
+		return wrapErrorf(ErrInvalidScalePattern, "%012b", pattern)
+	}
+	if degrees == nil {
+		if pattern.CountNotes() != 7 {
+			return wrapErrorf(ErrInvalidScalePattern, "%012b: missing degrees", pattern)
+		}
+		return nil
+	}
+	if len(degrees) != pattern.CountNotes() {
+		return wrapErrorf(ErrInvalidScalePattern,
+			"%012b: has %d notes but %d degrees", pattern, pattern.CountNotes(), len(degrees),
+		)
+	}
+	if degrees[0] != 1 {
+		return wrapErrorf(ErrInvalidScalePattern, "%012b: first degree should be 1", pattern)
+	}
+	for i := 1; i < len(degrees); i++ {
+		if degrees[i] < degrees[i-1] || degrees[i] > 7 {
+			return wrapErrorf(ErrInvalidScalePattern, "%012b: invalid degrees %v", pattern, degrees)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package gohar
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestScaleCatalogModes(t *testing.T) {
+	testCases := []struct {
+		Parent ScalePattern
+		Modes  []ScalePattern
+	}{
+		{
+			ScalePatternMajor,
+			[]ScalePattern{
+				ScalePatternMajor, ScalePatternDorian, ScalePatternPhrygian, ScalePatternLydian,
+				ScalePatternMixolydian, ScalePatternNaturalMinor, ScalePatternLocrian,
+			},
+		}, {
+			ScalePatternMelodicMinor,
+			[]ScalePattern{
+				ScalePatternMelodicMinor, ScalePatternDorianFlat2, ScalePatternLydianAugmented,
+				ScalePatternLydianDominant, ScalePatternMixolydianFlat6, ScalePatternLocrianNatural2,
+				ScalePatternAltered,
+			},
+		}, {
+			ScalePatternHarmonicMinor,
+			[]ScalePattern{
+				ScalePatternHarmonicMinor, ScalePatternLocrianNatural6, ScalePatternIonianAugmented,
+				ScalePatternDorianSharp4, ScalePatternPhrygianDominant, ScalePatternLydianSharp2,
+				ScalePatternAlteredDiminished,
+			},
+		}, {
+			ScalePatternHarmonicMajor,
+			[]ScalePattern{
+				ScalePatternHarmonicMajor, ScalePatternDorianFlat5, ScalePatternPhrygianFlat4,
+				ScalePatternLydianFlat3, ScalePatternMixolydianFlat2, ScalePatternLydianAugmentedSharp2,
+				ScalePatternLocrianDiminished7,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for degree, want := range tc.Modes {
+			got, err := tc.Parent.Mode(degree + 1)
+			Expect(t,
+				NoError(err),
+				Equalf(want, got, "mode %d of %012b", degree+1, tc.Parent),
+			)
+		}
+	}
+
+	minorPentatonic, err := ScalePatternMajorPentatonic.Mode(5)
+	Expect(t, NoError(err), Equal(ScalePatternMinorPentatonic, minorPentatonic))
+}
+
+func TestScaleCatalog(t *testing.T) {
+	var patterns []ScalePattern
+	for pattern := range ScalePatterns() {
+		Expect(t,
+			SliceDoesNotContain(pattern, patterns),
+			NoError(checkScalePattern(pattern, pattern.Degrees())),
+		)
+		for _, loc := range []*Locale{&LocaleEnglish, &LocaleFrench} {
+			_, err := loc.ScalePatternName(pattern)
+			Expect(t, NoError(err))
+		}
+		patterns = append(patterns, pattern)
+	}
+}
+
+func TestScalePatternDegrees(t *testing.T) {
+	testCases := []struct {
+		Name string
+		ScalePattern
+		Want []PitchClass
+	}{
+		{
+			"major", ScalePatternMajor,
+			[]PitchClass{
+				PitchClassC, PitchClassD, PitchClassE, PitchClassF,
+				PitchClassG, PitchClassA, PitchClassB,
+			},
+		}, {
+			"altered", ScalePatternAltered,
+			[]PitchClass{
+				PitchClassC, PitchClassD.Flat(), PitchClassE.Flat(), PitchClassF.Flat(),
+				PitchClassG.Flat(), PitchClassA.Flat(), PitchClassB.Flat(),
+			},
+		}, {
+			"blues", ScalePatternBlues,
+			[]PitchClass{
+				PitchClassC, PitchClassE.Flat(), PitchClassF,
+				PitchClassG.Flat(), PitchClassG, PitchClassB.Flat(),
+			},
+		}, {
+			"whole tone", ScalePatternWholeTone,
+			[]PitchClass{
+				PitchClassC, PitchClassD, PitchClassE,
+				PitchClassF.Sharp(), PitchClassG.Sharp(), PitchClassB.Flat(),
+			},
+		}, {
+			"half-whole diminished", ScalePatternDiminishedHalfWhole,
+			[]PitchClass{
+				PitchClassC, PitchClassD.Flat(), PitchClassD.Sharp(), PitchClassE,
+				PitchClassF.Sharp(), PitchClassG, PitchClassA, PitchClassB.Flat(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		got := slices.Collect(tc.PitchClassesWithDegrees(PitchClassC, tc.Degrees()))
+		Expect(t, Equalf(tc.Want, got, "%s", tc.Name))
+	}
+}
+
+func TestRegisterScalePattern(t *testing.T) {
+	catalog := slices.Clone(scaleCatalog)
+	t.Cleanup(func() { scaleCatalog = catalog })
+
+	const hirajoshi ScalePattern = 0b000110001101 // C D Eb G Ab
+	loc := Locale{}
+
+	Expect(t,
+		IsError(ErrInvalidScalePattern, RegisterScalePattern(0b100, []int8{1}, nil)),
+		IsError(ErrInvalidScalePattern, RegisterScalePattern(1<<12|1, []int8{1, 1}, nil)),
+		IsError(ErrInvalidScalePattern, RegisterScalePattern(hirajoshi, nil, nil)),
+		IsError(ErrInvalidScalePattern, RegisterScalePattern(hirajoshi, []int8{1, 2, 3}, nil)),
+		IsError(ErrInvalidScalePattern, RegisterScalePattern(hirajoshi, []int8{1, 3, 2, 5, 6}, nil)),
+		IsError(ErrInvalidScalePattern, RegisterScalePattern(hirajoshi, []int8{2, 3, 4, 5, 6}, nil)),
+	)
+
+	Require(t,
+		NoError(RegisterScalePattern(hirajoshi, []int8{1, 2, 3, 5, 6}, map[*Locale]string{
+			&loc: "hirajoshi",
+		})),
+	)
+	t.Cleanup(func() { delete(scaleDegrees, hirajoshi) })
+
+	name, err := loc.ScalePatternName(hirajoshi)
+	Expect(t,
+		NoError(err),
+		Equal("hirajoshi", name),
+		SliceContains(hirajoshi, slices.Collect(ScalePatterns())),
+		Equal([]int8{1, 2, 3, 5, 6}, hirajoshi.Degrees()),
+	)
+}
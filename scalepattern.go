@@ -60,8 +60,9 @@ func (s ScalePattern) Intervals() iter.Seq[Interval] {
 
 // IntervalsWithDegrees converts the scale pattern into intervals relative to the tonic.
 // If degrees == nil, the scale is assumed to have stepwise motion, which is suitable
-// for most common scales in western music (heptatonic scales).
+// for most common scales in western music (heptatonic scales): other scales yield nothing.
 // Otherwise, degrees describe the absolute pitch class intervals to use.
+// The degrees of the known scale patterns are given by [ScalePattern.Degrees].
 //
 // Eg. for a major pentatonic scale (degrees are the same for minor):
 //
@@ -72,6 +73,9 @@ func (s ScalePattern) Intervals() iter.Seq[Interval] {
 //	majorPentatonic := ScalePattern(0b1010010101)
 //	majorPentatonic.IntervalsWithDegrees([]int8{1,2,3,5,6})
 func (s ScalePattern) IntervalsWithDegrees(degrees []int8) iter.Seq[Interval] {
+	if degrees == nil && s.CountNotes() == 7 {
+		return s.Intervals()
+	}
 	return func(yield func(Interval) bool) {
 		if len(degrees) != s.CountNotes() {
 			return
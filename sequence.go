@@ -0,0 +1,250 @@
+package gohar
+
+import (
+	"iter"
+	"slices"
+)
+
+// An Event is a note, a group of notes played together or a rest, during a
+// span of time, possibly with a chord symbol.
+type Event struct {
+	// Notes are the notes to play together. The event is a rest if empty.
+	Notes []Note
+	// Start is the time at which the event starts, relative to the start of
+	// the music.
+	Start    Duration
+	Duration Duration
+	// Tied is true if the notes are held into the next event that starts when
+	// this one ends and plays the same notes.
+	Tied bool
+	// Harmony is the chord symbol written at the start of the event, if any.
+	Harmony *Chord
+}
+
+// End returns the time at which the event stops.
+func (e Event) End() Duration {
+	return e.Start.Add(e.Duration)
+}
+
+// IsRest returns true if the event doesn't play any note.
+func (e Event) IsRest() bool {
+	return len(e.Notes) == 0
+}
+
+// Split splits the event at given time into two events, the first one being
+// tied to the second unless it is a rest, and keeping the chord symbol. It
+// returns false if the time isn't strictly within the event.
+func (e Event) Split(at Duration) (Event, Event, bool) {
+	if at.Compare(e.Start) <= 0 || at.Compare(e.End()) >= 0 {
+		return e, Event{}, false
+	}
+	first := Event{Notes: e.Notes, Start: e.Start, Duration: at.Sub(e.Start), Tied: !e.IsRest(), Harmony: e.Harmony}
+	second := Event{Notes: e.Notes, Start: at, Duration: e.End().Sub(at), Tied: e.Tied}
+	return first, second, true
+}
+
+// A Sequence is a list of timed events, sorted by start time. Events may
+// overlap, for instance when several voices are merged in the same sequence.
+type Sequence struct {
+	Events []Event
+	// TimeSignature is the meter used to divide the sequence in bars.
+	TimeSignature TimeSignature
+}
+
+// Append adds an event of given duration at the end of the sequence. The event
+// is a rest if no note is given.
+func (s *Sequence) Append(d Duration, notes ...Note) {
+	s.Events = append(s.Events, Event{Notes: slices.Clone(notes), Start: s.End(), Duration: d})
+}
+
+// AppendChord adds the chord at the end of the sequence, with its root at given
+// octave (see [Chord.Notes]) and its chord symbol.
+func (s *Sequence) AppendChord(d Duration, c Chord, oct int8) {
+	s.Events = append(s.Events, Event{Notes: slices.Collect(c.Notes(oct)), Start: s.End(), Duration: d, Harmony: &c})
+}
+
+// End returns the time at which the last event stops.
+func (s Sequence) End() Duration {
+	var end Duration
+	for _, e := range s.Events {
+		if e.End().Compare(end) > 0 {
+			end = e.End()
+		}
+	}
+	return end
+}
+
+// All iterates over the events of the sequence.
+func (s Sequence) All() iter.Seq[Event] {
+	return func(yield func(Event) bool) {
+		for _, e := range s.Events {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the part of the sequence between given times. Events that
+// cross the boundaries are cut, and stay tied to the rest of their notes. Times
+// aren't shifted, so that events keep their position in the music.
+func (s Sequence) Slice(from, to Duration) Sequence {
+	slice := Sequence{TimeSignature: s.TimeSignature}
+	for _, e := range s.Events {
+		overlaps := e.Start.Compare(to) < 0 &&
+			(e.End().Compare(from) > 0 || e.Duration.IsZero() && e.Start.Compare(from) >= 0)
+		if !overlaps {
+			continue
+		}
+		if _, second, ok := e.Split(from); ok {
+			e = second
+		}
+		if first, _, ok := e.Split(to); ok {
+			e = first
+		}
+		slice.Events = append(slice.Events, e)
+	}
+	return slice
+}
+
+// Bar returns the events of given bar, counting bars from 1 (see
+// [Sequence.Slice]).
+func (s Sequence) Bar(bar int) Sequence {
+	start := s.TimeSignature.BarStart(bar)
+	return s.Slice(start, start.Add(s.TimeSignature.Bar()))
+}
+
+// Bars iterates over the bars of the sequence, counting them from 1 (see
+// [Sequence.Bar]).
+func (s Sequence) Bars() iter.Seq2[int, Sequence] {
+	return func(yield func(int, Sequence) bool) {
+		end := s.End()
+		for bar := 1; s.TimeSignature.BarStart(bar).Compare(end) < 0; bar++ {
+			if !yield(bar, s.Bar(bar)) {
+				return
+			}
+		}
+	}
+}
+
+// SplitAtBars returns the sequence with events that cross bar lines split and
+// tied over them.
+func (s Sequence) SplitAtBars() Sequence {
+	split := Sequence{TimeSignature: s.TimeSignature}
+	for _, e := range s.Events {
+		for {
+			bar, _ := e.Start.Count(s.TimeSignature.Bar())
+			first, second, ok := e.Split(s.TimeSignature.BarStart(bar + 2))
+			if !ok {
+				break
+			}
+			split.Events = append(split.Events, first)
+			e = second
+		}
+		split.Events = append(split.Events, e)
+	}
+	slices.SortStableFunc(split.Events, compareEventStarts)
+	return split
+}
+
+// Voice returns the sequence as a single voice, as it is written on a staff:
+// the gaps between events are filled with rests, and events that cross bar
+// lines are split and tied over them.
+//
+// ErrInvalidSequence is returned if an event starts before the previous one
+// ends, or has a negative duration.
+func (s Sequence) Voice() (Sequence, error) {
+	voice := Sequence{TimeSignature: s.TimeSignature}
+	var end Duration
+	for _, e := range s.Events {
+		switch {
+		case e.Duration.Compare(Duration{}) < 0:
+			return Sequence{}, wrapErrorf(ErrInvalidSequence, "negative duration at %s", e.Start)
+		case e.Start.Compare(end) < 0:
+			return Sequence{}, wrapErrorf(ErrInvalidSequence, "overlapping events at %s", e.Start)
+		case e.Start.Compare(end) > 0:
+			voice.Events = append(voice.Events, Event{Start: end, Duration: e.Start.Sub(end)})
+		}
+		voice.Events = append(voice.Events, e)
+		end = e.End()
+	}
+	return voice.SplitAtBars(), nil
+}
+
+// MergeTies returns the sequence with tied events merged into single events.
+// It is the opposite of [Sequence.SplitAtBars].
+func (s Sequence) MergeTies() Sequence {
+	merged := Sequence{TimeSignature: s.TimeSignature}
+	used := make([]bool, len(s.Events))
+	for i, e := range s.Events {
+		if used[i] {
+			continue
+		}
+		for e.Tied {
+			j := s.nextTied(e, i+1, used)
+			if j < 0 {
+				break
+			}
+			used[j] = true
+			e.Duration = e.Duration.Add(s.Events[j].Duration)
+			e.Tied = s.Events[j].Tied
+		}
+		merged.Events = append(merged.Events, e)
+	}
+	return merged
+}
+
+// nextTied returns the index of the first unused event from given index that
+// continues e, or -1 if there is none.
+func (s Sequence) nextTied(e Event, from int, used []bool) int {
+	for j := from; j < len(s.Events); j++ {
+		next := s.Events[j]
+		if !used[j] && next.Start.Equal(e.End()) && slices.Equal(next.Notes, e.Notes) {
+			return j
+		}
+	}
+	return -1
+}
+
+// Merge returns the events of both sequences in a single sequence, with the
+// time signature of the first one.
+func (s Sequence) Merge(o Sequence) Sequence {
+	s.Events = slices.Concat(s.Events, o.Events)
+	slices.SortStableFunc(s.Events, compareEventStarts)
+	return s
+}
+
+// Transpose transposes every note of the sequence by given interval.
+func (s Sequence) Transpose(i Interval) Sequence {
+	events := make([]Event, len(s.Events))
+	for n, e := range s.Events {
+		notes := make([]Note, len(e.Notes))
+		for k, note := range e.Notes {
+			notes[k] = note.Transpose(i)
+		}
+		e.Notes = notes
+		if e.Harmony != nil {
+			harmony := e.Harmony.Transpose(i)
+			e.Harmony = &harmony
+		}
+		events[n] = e
+	}
+	s.Events = events
+	return s
+}
+
+func compareEventStarts(a, b Event) int {
+	return a.Start.Compare(b.Start)
+}
+
+// Sequence returns the chords of the progression as a sequence, with their root
+// at given octave (see [Chord.Notes]) and their chord symbol. Each beat of the progression lasts a beat
+// of given time signature, which should have as many beats per bar as the
+// progression for their bars to match.
+func (p Progression) Sequence(oct int8, ts TimeSignature) Sequence {
+	s := Sequence{TimeSignature: ts}
+	for c := range p.All() {
+		s.AppendChord(ts.Beat().Scale(c.Beats, 1), c.Chord, oct)
+	}
+	return s
+}
@@ -0,0 +1,208 @@
+package gohar
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+// testEvent is a note (or "" for a rest) lasting a number of eighth notes.
+type testEvent struct {
+	Note    string
+	Eighths int
+}
+
+// sequenceFrom builds a sequence from successive events.
+func sequenceFrom(t *testing.T, ts TimeSignature, events []testEvent) Sequence {
+	t.Helper()
+	s := Sequence{TimeSignature: ts}
+	for _, e := range events {
+		d := DurationEighth.Scale(e.Eighths, 1)
+		if e.Note == "" {
+			s.Append(d)
+			continue
+		}
+		n, err := ParseNote(e.Note)
+		Require(t, NoError(err))
+		s.Append(d, n)
+	}
+	return s
+}
+
+func eventStrings(s Sequence) []string {
+	var events []string
+	for e := range s.All() {
+		notes := "r"
+		if !e.IsRest() {
+			notes = e.Notes[0].PitchClass.String()
+		}
+		tie := ""
+		if e.Tied {
+			tie = "~"
+		}
+		events = append(events, notes+"@"+e.Start.String()+":"+e.Duration.String()+tie)
+	}
+	return events
+}
+
+func TestEventSplit(t *testing.T) {
+	e := Event{Notes: []Note{NoteC}, Start: DurationQuarter, Duration: DurationHalf}
+	first, second, ok := e.Split(DurationHalf)
+	Expect(t,
+		IsTrue(ok),
+		Equal(Event{Notes: []Note{NoteC}, Start: DurationQuarter, Duration: DurationQuarter, Tied: true}, first),
+		Equal(Event{Notes: []Note{NoteC}, Start: DurationHalf, Duration: DurationQuarter}, second),
+		Equal(e.End(), second.End()),
+	)
+
+	for _, at := range []Duration{Duration{}, DurationQuarter, NewDuration(3, 4), DurationWhole} {
+		_, _, ok := e.Split(at)
+		Expect(t, IsTruef(!ok, "split at %s", at))
+	}
+
+	rest := Event{Start: Duration{}, Duration: DurationHalf}
+	first, _, ok = rest.Split(DurationQuarter)
+	Expect(t, IsTrue(ok), IsTrue(!first.Tied))
+
+	c := chordFromSymbol(t, "C7")
+	e.Harmony = &c
+	first, second, _ = e.Split(DurationHalf)
+	Expect(t, Equal(&c, first.Harmony), IsTrue(second.Harmony == nil))
+}
+
+func TestSequenceBars(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		TimeSignature TimeSignature
+		Events        []testEvent
+		Bars          [][]string
+	}{
+		{
+			"common time", TimeSignature{},
+			[]testEvent{{"C", 2}, {"D", 2}, {"E", 4}, {"F", 8}},
+			[][]string{
+				{"C@0:1/4", "D@1/4:1/4", "E@1/2:1/2"},
+				{"F@1:1"},
+			},
+		},
+		{
+			"over bar lines", TimeSignatureWaltz,
+			[]testEvent{{"C", 4}, {"", 2}, {"G", 13}},
+			[][]string{
+				{"C@0:1/2", "r@1/2:1/4"},
+				{"G@3/4:3/4~"},
+				{"G@3/2:3/4~"},
+				{"G@9/4:1/8"},
+			},
+		},
+		{
+			"compound", TimeSignature{6, 8},
+			[]testEvent{{"A", 3}, {"B", 6}, {"C", 3}},
+			[][]string{
+				{"A@0:3/8", "B@3/8:3/8~"},
+				{"B@3/4:3/8", "C@9/8:3/8"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := sequenceFrom(t, tc.TimeSignature, tc.Events)
+			var bars [][]string
+			for n, bar := range s.Bars() {
+				Expect(t, Equal(len(bars)+1, n), Equal(bar, s.Bar(n)))
+				bars = append(bars, eventStrings(bar))
+			}
+			Expect(t, Equal(tc.Bars, bars))
+
+			split := s.SplitAtBars()
+			Expect(t,
+				Equal(slices.Concat(tc.Bars...), eventStrings(split)),
+				Equal(s, split.MergeTies()),
+				Equal(s.End(), split.End()),
+			)
+		})
+	}
+}
+
+func TestSequenceSlice(t *testing.T) {
+	s := sequenceFrom(t, TimeSignature{}, []testEvent{{"C", 4}, {"D", 4}, {"E", 8}})
+	s.Events = append(s.Events, Event{Notes: []Note{NoteG}, Start: NewDuration(3, 2)})
+	Expect(t,
+		Equal([]string{"C@1/4:1/4", "D@1/2:1/4~"}, eventStrings(s.Slice(DurationQuarter, NewDuration(3, 4)))),
+		Equal([]string{"E@1:3/4~", "G@3/2:0"}, eventStrings(s.Slice(DurationWhole, NewDuration(7, 4)))),
+		IsEmptySlice(s.Slice(NewDuration(2, 1), NewDuration(3, 1)).Events),
+	)
+}
+
+func TestSequenceMerge(t *testing.T) {
+	upper := sequenceFrom(t, TimeSignatureWaltz, []testEvent{{"E", 2}, {"F", 2}, {"G", 2}})
+	lower := sequenceFrom(t, TimeSignature{}, []testEvent{{"C", 6}})
+	merged := upper.Merge(lower)
+	Expect(t,
+		Equal([]string{"E@0:1/4", "C@0:3/4", "F@1/4:1/4", "G@1/2:1/4"}, eventStrings(merged)),
+		Equal(TimeSignatureWaltz, merged.TimeSignature),
+		Equal(NewDuration(3, 4), merged.End()),
+		SliceHasLength(3, upper.Events),
+	)
+}
+
+func TestSequenceVoice(t *testing.T) {
+	s := Sequence{TimeSignature: TimeSignatureWaltz, Events: []Event{
+		{Notes: []Note{NoteC}, Start: DurationQuarter, Duration: DurationQuarter},
+		{Notes: []Note{NoteD}, Start: DurationHalf, Duration: DurationHalf},
+		{Notes: []Note{NoteE}, Start: NewDuration(3, 2), Duration: DurationQuarter},
+	}}
+	voice, err := s.Voice()
+	Require(t, NoError(err))
+	Expect(t,
+		Equal(
+			[]string{"r@0:1/4", "C@1/4:1/4", "D@1/2:1/4~", "D@3/4:1/4", "r@1:1/2", "E@3/2:1/4"},
+			eventStrings(voice),
+		),
+		Equal(TimeSignatureWaltz, voice.TimeSignature),
+	)
+
+	s.Events[1].Start = NewDuration(3, 8)
+	_, err = s.Voice()
+	Expect(t, IsError(ErrInvalidSequence, err))
+
+	s.Events[1] = Event{Start: DurationHalf, Duration: NewDuration(-1, 4)}
+	_, err = s.Voice()
+	Expect(t, IsError(ErrInvalidSequence, err))
+}
+
+func TestSequenceMergeTies(t *testing.T) {
+	s := Sequence{Events: []Event{
+		{Notes: []Note{NoteC}, Start: Duration{}, Duration: DurationQuarter, Tied: true},
+		{Notes: []Note{NoteE}, Start: DurationQuarter, Duration: DurationQuarter},
+		{Notes: []Note{NoteC}, Start: DurationQuarter, Duration: DurationHalf, Tied: true},
+		{Notes: []Note{NoteD}, Start: NewDuration(3, 4), Duration: DurationQuarter, Tied: true},
+	}}
+	Expect(t, Equal(
+		[]string{"C@0:3/4~", "E@1/4:1/4", "D@3/4:1/4~"},
+		eventStrings(s.MergeTies()),
+	))
+}
+
+func TestProgressionSequence(t *testing.T) {
+	p, err := ParseProgression("| Dm7 G7 | C |", 2)
+	Require(t, NoError(err))
+	s := p.Sequence(0, TimeSignature{6, 8})
+	g7 := chordFromSymbol(t, "G7")
+	Expect(t,
+		Equal([]string{"D@0:3/8", "G@3/8:3/8", "C@3/4:3/4"}, eventStrings(s)),
+		Equal(slices.Collect(g7.Notes(0)), s.Events[1].Notes),
+		Equal(&g7, s.Events[1].Harmony),
+	)
+
+	transposed := s.Transpose(IntMajorSecond)
+	a7 := chordFromSymbol(t, "A7")
+	Expect(t,
+		Equal([]string{"E@0:3/8", "A@3/8:3/8", "D@3/4:3/4"}, eventStrings(transposed)),
+		Equal(&a7, transposed.Events[1].Harmony),
+		Equal(NoteD, s.Events[0].Notes[0]),
+		Equal(&g7, s.Events[1].Harmony),
+	)
+}
@@ -0,0 +1,155 @@
+package gohar
+
+// A SpellingContext provides the information used by [Spell] to pick the
+// spelling of pitches. Every field is optional.
+type SpellingContext struct {
+	// Scale is the key or the scale of the pitches. Pitches that belong to the
+	// scale are spelled after it. If the scale isn't set, the major key that
+	// fits the pitches best is used.
+	Scale Scale
+	// Chord is the chord the pitches belong to. Chord tones are spelled after
+	// the chord, even if they don't belong to the scale.
+	Chord Chord
+	// Melodic tells that the pitches are a melodic line rather than a set:
+	// chromatic pitches are spelled after the direction of the line, with
+	// sharps when going up and flats when going down.
+	Melodic bool
+}
+
+// Spell returns the notes with the best spelling for the pitches, given the
+// spelling context.
+//
+// Pitches are spelled after the chord, then after the scale. The remaining
+// (chromatic) pitches are spelled so as to minimize accidentals, stay close to
+// the key signature and avoid repeating note names.
+func Spell(pitches []Pitch, ctx SpellingContext) []Note {
+	if len(pitches) == 0 {
+		return nil
+	}
+	fixed := make(map[Pitch]PitchClass)
+	if ctx.Scale.Pattern == 0 {
+		ctx.Scale = inferKey(pitches)
+	}
+	for pc := range ctx.Scale.PitchClasses() {
+		fixed[pc.Pitch(0).Normalize()] = pc
+	}
+	if ctx.Chord.Root.IsValid() {
+		for pc := range ctx.Chord.PitchClasses() {
+			fixed[pc.Pitch(0).Normalize()] = pc
+		}
+	}
+	fifths := fifthsOf(ctx.Scale.Root)
+	if _, ok := majorModeDegree(ctx.Scale.Pattern); ok {
+		fifths = Key{ctx.Scale}.Fifths()
+	}
+
+	// note names used by the pitches that have a fixed spelling
+	var letters [7]bool
+	for _, p := range pitches {
+		if pc, ok := fixed[p.Normalize()]; ok {
+			letters[pc.Base()] = true
+		}
+	}
+
+	notes := make([]Note, len(pitches))
+	for i, p := range pitches {
+		pc, ok := fixed[p.Normalize()]
+		if !ok {
+			var direction Pitch
+			if ctx.Melodic {
+				direction = melodicDirection(pitches, i)
+			}
+			pc = bestSpelling(p, fifths, letters, direction)
+			if !ctx.Melodic {
+				fixed[p.Normalize()] = pc
+				letters[pc.Base()] = true
+			}
+		}
+		notes[i] = NoteWithPitch(pc, p)
+	}
+	return notes
+}
+
+// melodicDirection returns the direction of the line around the i-th pitch.
+func melodicDirection(pitches []Pitch, i int) Pitch {
+	switch {
+	case i+1 < len(pitches):
+		return pitches[i+1] - pitches[i]
+	case i > 0:
+		return pitches[i] - pitches[i-1]
+	}
+	return 0
+}
+
+// bestSpelling returns the spelling of a chromatic pitch with the lowest cost.
+func bestSpelling(p Pitch, fifths int, letters [7]bool, direction Pitch) PitchClass {
+	var (
+		best     PitchClass
+		bestCost int
+	)
+	for _, pc := range spellings(p) {
+		alt := int(pc.Alt())
+		cost := 2 * alt * alt
+		// distance to the diatonic notes of the key, along the line of fifths
+		if f := fifthsOf(pc); f < fifths-1 {
+			cost += fifths - 1 - f
+		} else if f > fifths+5 {
+			cost += f - fifths - 5
+		}
+		if letters[pc.Base()] {
+			cost += 3
+		}
+		if (direction > 0 && alt < 0) || (direction < 0 && alt > 0) {
+			cost += 3
+		}
+		// on equal costs, prefer sharps in sharp keys and flats otherwise
+		if best == 0 || cost < bestCost || (cost == bestCost && (alt > 0) == (fifths > 0)) {
+			best, bestCost = pc, cost
+		}
+	}
+	return best
+}
+
+// spellings returns the pitch classes with at most two accidentals that
+// correspond to the pitch.
+func spellings(p Pitch) []PitchClass {
+	var pcs []PitchClass
+	for base, natural := range asPitch {
+		alt := (p - natural + 6).Normalize() - 6
+		if -2 <= alt && alt <= 2 {
+			pcs = append(pcs, PitchClassC.MoveBase(int8(base)).WithAlt(alt))
+		}
+	}
+	return pcs
+}
+
+// inferKey returns the major key that contains most of the pitches, with as
+// few accidentals as possible. Sharps are preferred on equal terms.
+func inferKey(pitches []Pitch) Scale {
+	var set ScalePattern
+	for _, p := range pitches {
+		set |= 1 << p.Normalize()
+	}
+	var (
+		best      Key
+		bestScore = -1
+	)
+	for _, fifths := range []int{0, 1, -1, 2, -2, 3, -3, 4, -4, 5, -5, 6, -6, 7, -7} {
+		key := NewMajorKey(PitchClassC).Step(fifths)
+		relative := rotatePattern(set, int(key.Root.Pitch(0).Normalize()))
+		if score := (relative & key.Pattern).CountNotes(); score > bestScore {
+			best, bestScore = key, score
+		}
+	}
+	return best.Scale
+}
+
+// SpellPitchClasses is like [Spell], for pitch classes rather than notes.
+func SpellPitchClasses(pitches []Pitch, ctx SpellingContext) []PitchClass {
+	notes := Spell(pitches, ctx)
+	pcs := make([]PitchClass, len(notes))
+	for i, n := range notes {
+		pcs[i] = n.PitchClass
+	}
+	return pcs
+}
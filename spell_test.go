@@ -0,0 +1,83 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestSpell(t *testing.T) {
+	Expect(t, IsEmptySlice(Spell(nil, SpellingContext{})))
+
+	pcs := func(pcs ...PitchClass) []PitchClass { return pcs }
+	testCases := []struct {
+		Name    string
+		Pitches []Pitch
+		Context SpellingContext
+		Want    []PitchClass
+	}{
+		{
+			"F♯ major", []Pitch{6, 8, 10, 11, 13, 15, 17},
+			SpellingContext{},
+			pcs(
+				PitchClassF.Sharp(), PitchClassG.Sharp(), PitchClassA.Sharp(), PitchClassB,
+				PitchClassC.Sharp(), PitchClassD.Sharp(), PitchClassE.Sharp(),
+			),
+		},
+		{
+			"D♭ major", []Pitch{1, 3, 5, 6, 8, 10, 12},
+			SpellingContext{},
+			pcs(
+				PitchClassD.Flat(), PitchClassE.Flat(), PitchClassF, PitchClassG.Flat(),
+				PitchClassA.Flat(), PitchClassB.Flat(), PitchClassC,
+			),
+		},
+		{
+			"E major triad", []Pitch{4, 8, 11},
+			SpellingContext{},
+			pcs(PitchClassE, PitchClassG.Sharp(), PitchClassB),
+		},
+		{
+			"in key", []Pitch{6, 10},
+			SpellingContext{Scale: Scale{PitchClassG.Flat(), ScalePatternMajor}},
+			pcs(PitchClassG.Flat(), PitchClassB.Flat()),
+		},
+		{
+			"chromatic in key", []Pitch{PitchC, PitchEFlat, PitchFSharp},
+			SpellingContext{Scale: Scale{PitchClassC, ScalePatternMajor}},
+			pcs(PitchClassC, PitchClassE.Flat(), PitchClassF.Sharp()),
+		},
+		{
+			"no repeated letters", []Pitch{PitchC, PitchE, PitchG, PitchA, PitchBFlat, PitchDFlat + 12},
+			SpellingContext{Scale: Scale{PitchClassC, ScalePatternMajor}},
+			pcs(PitchClassC, PitchClassE, PitchClassG, PitchClassA, PitchClassB.Flat(), PitchClassD.Flat()),
+		},
+		{
+			"chord", []Pitch{PitchC, PitchE, PitchG, PitchBFlat, PitchEFlat + 12},
+			SpellingContext{
+				Scale: Scale{PitchClassC, ScalePatternMajor},
+				Chord: Chord{Root: PitchClassC, Pattern: ChordPattern7.Add(PitchDiffAugmentedNinth)},
+			},
+			pcs(PitchClassC, PitchClassE, PitchClassG, PitchClassB.Flat(), PitchClassD.Sharp()),
+		},
+		{
+			"melodic", []Pitch{PitchC, PitchCSharp, PitchD, PitchDFlat, PitchC},
+			SpellingContext{Scale: Scale{PitchClassC, ScalePatternMajor}, Melodic: true},
+			pcs(PitchClassC, PitchClassC.Sharp(), PitchClassD, PitchClassD.Flat(), PitchClassC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			Expect(t, Equal(tc.Want, SpellPitchClasses(tc.Pitches, tc.Context)))
+		})
+	}
+
+	t.Run("octaves", func(t *testing.T) {
+		cSharpMajor := SpellingContext{Scale: Scale{PitchClassC.Sharp(), ScalePatternMajor}}
+		Expect(t, Equal(
+			[]Note{NoteB.Octave(-1), NoteB.Sharp().Octave(0), NoteB.Sharp().Octave(1)},
+			Spell([]Pitch{-1, 0, 12}, cSharpMajor),
+		))
+	})
+}
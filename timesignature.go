@@ -0,0 +1,145 @@
+package gohar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A TimeSignature is the meter of a piece: Beats notes of value 1/BeatType in
+// each bar.
+//
+// The zero TimeSignature, like any time signature that isn't positive, is
+// handled as DefaultTimeSignature.
+type TimeSignature struct {
+	Beats, BeatType int
+}
+
+// DefaultTimeSignature is the time signature used when none is specified.
+var DefaultTimeSignature = TimeSignature{DefaultBeatsPerBar, 4}
+
+// Common time signatures.
+var (
+	TimeSignatureCommon = TimeSignature{4, 4}
+	TimeSignatureCut    = TimeSignature{2, 2}
+	TimeSignatureWaltz  = TimeSignature{3, 4}
+)
+
+// NewTimeSignature returns the time signature beats/beatType.
+//
+// ErrInvalidTimeSignature is returned if beats isn't positive, or if beatType
+// isn't a power of 2.
+func NewTimeSignature(beats, beatType int) (TimeSignature, error) {
+	if beats <= 0 || !isPowerOf2(beatType) {
+		return TimeSignature{}, wrapErrorf(ErrInvalidTimeSignature, "%d/%d", beats, beatType)
+	}
+	return TimeSignature{beats, beatType}, nil
+}
+
+// ParseTimeSignature parses a time signature written as a fraction, such as
+// "3/4" or "6/8", or as "C" (common time) or "C|" (cut time).
+//
+// ErrInvalidTimeSignature is returned if the input cannot be parsed, or if the
+// time signature is invalid (see [NewTimeSignature]).
+func ParseTimeSignature(input string) (TimeSignature, error) {
+	input = strings.TrimSpace(input)
+	switch input {
+	case "C":
+		return TimeSignatureCommon, nil
+	case "C|":
+		return TimeSignatureCut, nil
+	}
+	beats, beatType, ok := strings.Cut(input, "/")
+	if !ok {
+		return TimeSignature{}, wrapErrorf(ErrInvalidTimeSignature, "%q", input)
+	}
+	b, err := strconv.Atoi(strings.TrimSpace(beats))
+	if err != nil {
+		return TimeSignature{}, wrapErrorf(ErrInvalidTimeSignature, "%q", input)
+	}
+	bt, err := strconv.Atoi(strings.TrimSpace(beatType))
+	if err != nil {
+		return TimeSignature{}, wrapErrorf(ErrInvalidTimeSignature, "%q", input)
+	}
+	return NewTimeSignature(b, bt)
+}
+
+func (ts TimeSignature) norm() TimeSignature {
+	if ts.Beats <= 0 || ts.BeatType <= 0 {
+		return DefaultTimeSignature
+	}
+	return ts
+}
+
+// IsCompound returns true if the beats of the time signature are divided in
+// three, such as 6/8 or 12/8.
+func (ts TimeSignature) IsCompound() bool {
+	ts = ts.norm()
+	return ts.Beats > 3 && ts.Beats%3 == 0 && ts.BeatType >= 8
+}
+
+// BeatsPerBar returns the number of beats felt in each bar: the beats of a
+// compound time signature are dotted, so that 6/8 has two beats per bar.
+func (ts TimeSignature) BeatsPerBar() int {
+	ts = ts.norm()
+	if ts.IsCompound() {
+		return ts.Beats / 3
+	}
+	return ts.Beats
+}
+
+// Beat returns the duration of a beat, such as a dotted quarter note in 6/8.
+func (ts TimeSignature) Beat() Duration {
+	ts = ts.norm()
+	if ts.IsCompound() {
+		return NewDuration(3, ts.BeatType)
+	}
+	return NewDuration(1, ts.BeatType)
+}
+
+// Bar returns the duration of a bar.
+func (ts TimeSignature) Bar() Duration {
+	ts = ts.norm()
+	return NewDuration(ts.Beats, ts.BeatType)
+}
+
+// BarStart returns the time at which given bar starts, counting bars from 1.
+func (ts TimeSignature) BarStart(bar int) Duration {
+	return ts.Bar().Scale(bar-1, 1)
+}
+
+// Position returns the bar and beat at given time.
+func (ts TimeSignature) Position(t Duration) Position {
+	bar, t := t.Count(ts.Bar())
+	beat, offset := t.Count(ts.Beat())
+	return Position{Bar: bar + 1, Beat: beat + 1, Offset: offset}
+}
+
+// Time returns the time of given position. It is the opposite of
+// [TimeSignature.Position].
+func (ts TimeSignature) Time(p Position) Duration {
+	return ts.BarStart(p.Bar).Add(ts.Beat().Scale(p.Beat-1, 1)).Add(p.Offset)
+}
+
+// String returns the time signature as a fraction, such as "6/8".
+func (ts TimeSignature) String() string {
+	ts = ts.norm()
+	return fmt.Sprintf("%d/%d", ts.Beats, ts.BeatType)
+}
+
+// A Position is a point in time, expressed in bars and beats.
+type Position struct {
+	// Bar and Beat are counted from 1.
+	Bar, Beat int
+	// Offset is the time elapsed since the start of the beat.
+	Offset Duration
+}
+
+// String returns the position as "bar:beat", such as "3:2", followed by the
+// offset within the beat if any, such as "3:2+1/8".
+func (p Position) String() string {
+	if p.Offset.IsZero() {
+		return fmt.Sprintf("%d:%d", p.Bar, p.Beat)
+	}
+	return fmt.Sprintf("%d:%d+%s", p.Bar, p.Beat, p.Offset)
+}
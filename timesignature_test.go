@@ -0,0 +1,79 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestParseTimeSignature(t *testing.T) {
+	testCases := []struct {
+		Input       string
+		Want        TimeSignature
+		BeatsPerBar int
+		Beat, Bar   Duration
+	}{
+		{"4/4", TimeSignatureCommon, 4, DurationQuarter, DurationWhole},
+		{"C", TimeSignatureCommon, 4, DurationQuarter, DurationWhole},
+		{"C|", TimeSignatureCut, 2, DurationHalf, DurationWhole},
+		{"3/4", TimeSignatureWaltz, 3, DurationQuarter, NewDuration(3, 4)},
+		{"3/8", TimeSignature{3, 8}, 3, DurationEighth, NewDuration(3, 8)},
+		{"6/8", TimeSignature{6, 8}, 2, NewDuration(3, 8), NewDuration(3, 4)},
+		{" 12 / 8 ", TimeSignature{12, 8}, 4, NewDuration(3, 8), NewDuration(3, 2)},
+		{"5/4", TimeSignature{5, 4}, 5, DurationQuarter, NewDuration(5, 4)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Input, func(t *testing.T) {
+			ts, err := ParseTimeSignature(tc.Input)
+			Require(t, NoError(err))
+			Expect(t,
+				Equal(tc.Want, ts),
+				Equal(tc.BeatsPerBar, ts.BeatsPerBar()),
+				Equal(tc.Beat, ts.Beat()),
+				Equal(tc.Bar, ts.Bar()),
+			)
+		})
+	}
+
+	for _, input := range []string{"", "4", "3/0", "3/6", "0/4", "-2/4", "a/4", "3/b"} {
+		_, err := ParseTimeSignature(input)
+		Expect(t, IsErrorf(ErrInvalidTimeSignature, err, "%q", input))
+	}
+
+	Expect(t,
+		Equal("4/4", TimeSignature{}.String()),
+		Equal(DurationWhole, TimeSignature{}.Bar()),
+	)
+}
+
+func TestTimeSignaturePosition(t *testing.T) {
+	testCases := []struct {
+		TimeSignature TimeSignature
+		Time          Duration
+		Want          string
+	}{
+		{TimeSignatureCommon, Duration{}, "1:1"},
+		{TimeSignatureCommon, NewDuration(5, 4), "2:2"},
+		{TimeSignatureCommon, NewDuration(11, 8), "2:2+1/8"},
+		{TimeSignatureWaltz, NewDuration(3, 2), "3:1"},
+		{TimeSignature{6, 8}, NewDuration(9, 8), "2:2"},
+		{TimeSignature{6, 8}, NewDuration(7, 8), "2:1+1/8"},
+		{TimeSignatureCommon, DurationQuarter.Tuplet(3, 2), "1:1+1/6"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.TimeSignature.String()+"@"+tc.Time.String(), func(t *testing.T) {
+			p := tc.TimeSignature.Position(tc.Time)
+			Expect(t,
+				Equal(tc.Want, p.String()),
+				Equal(tc.Time, tc.TimeSignature.Time(p)),
+			)
+		})
+	}
+
+	Expect(t,
+		Equal(Duration{}, TimeSignatureWaltz.BarStart(1)),
+		Equal(NewDuration(9, 4), TimeSignatureWaltz.BarStart(4)),
+	)
+}
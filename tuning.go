@@ -0,0 +1,249 @@
+package gohar
+
+import (
+	"math"
+)
+
+// A Tuning maps notes to frequencies.
+//
+// Depending on the tuning system, enharmonic notes may have different
+// frequencies: in meantone temperaments, C♯ is lower than D♭.
+type Tuning interface {
+	// Frequency returns the frequency of the note, in Hz.
+	Frequency(n Note) float64
+}
+
+// An EqualTemperament tunes pitches in twelve-tone equal temperament (12-TET),
+// where enharmonic notes have the same frequency.
+type EqualTemperament struct {
+	// ConcertA is the frequency of the A above middle C (pitch 9), in Hz.
+	// The zero value stands for 440 Hz.
+	ConcertA float64
+}
+
+var (
+	// StandardTuning is the ISO 16 standard, with A = 440 Hz.
+	StandardTuning = EqualTemperament{ConcertA: 440}
+	// OrchestraTuning is the tuning used by many european orchestras, with A = 442 Hz.
+	OrchestraTuning = EqualTemperament{ConcertA: 442}
+	// BaroqueTuning is the usual tuning of baroque music performances, with A = 415 Hz.
+	BaroqueTuning = EqualTemperament{ConcertA: 415}
+)
+
+// concertA returns the frequency of A, or 440 Hz if it isn't set.
+func concertA(hz float64) float64 {
+	if hz == 0 {
+		return StandardTuning.ConcertA
+	}
+	return hz
+}
+
+// Frequency returns the frequency of the note, in Hz.
+func (t EqualTemperament) Frequency(n Note) float64 {
+	return concertA(t.ConcertA) * math.Exp2(float64(n.Pitch()-PitchA)/12)
+}
+
+// Frequency returns the frequency of the pitch in given tuning, in Hz.
+// The pitch is spelled with [DefaultPitchClass], which matters for tunings
+// where enharmonic notes differ. The standard tuning is used if ref is nil.
+func (p Pitch) Frequency(ref Tuning) float64 {
+	if ref == nil {
+		ref = StandardTuning
+	}
+	return ref.Frequency(NoteWithPitch(DefaultPitchClass(p), p))
+}
+
+// PitchFromFrequency returns the closest pitch to given (positive) frequency in
+// Hz, along with the distance in cents from that pitch to the frequency, in the
+// [-50; 50] range. Frequencies that are out of the range of pitches are clipped
+// to the lowest or highest pitch.
+//
+// This method panics if hz isn't positive.
+func (t EqualTemperament) PitchFromFrequency(hz float64) (Pitch, float64) {
+	if !(hz > 0) {
+		panic("gohar: pitch of a non-positive frequency")
+	}
+	semitones := 12*math.Log2(hz/concertA(t.ConcertA)) + float64(PitchA)
+	p := Pitch(math.Round(min(max(semitones, math.MinInt8), math.MaxInt8)))
+	return p, 100 * (semitones - float64(p))
+}
+
+// PitchFromFrequency is like [EqualTemperament.PitchFromFrequency] in the
+// standard tuning (A = 440 Hz). It panics if hz isn't positive.
+func PitchFromFrequency(hz float64) (Pitch, float64) {
+	return StandardTuning.PitchFromFrequency(hz)
+}
+
+// Cents returns the distance between two frequencies in cents (hundredths of an
+// equally tempered semitone). The result is negative if to is lower than from.
+func Cents(from, to float64) float64 {
+	return 1200 * math.Log2(to/from)
+}
+
+// AddCents returns the frequency that is given number of cents above (or below,
+// if cents < 0) the frequency hz.
+func AddCents(hz, cents float64) float64 {
+	return hz * math.Exp2(cents/1200)
+}
+
+// A RegularTemperament tunes notes after their position on the line of fifths
+// (... B♭ F C G D A E B F♯ ...): every fifth has the same size, and octaves are
+// pure. This covers pythagorean tuning, meantone temperaments and most equal
+// divisions of the octave.
+type RegularTemperament struct {
+	// Fifth is the size of the fifth, in cents.
+	Fifth float64
+	// ConcertA is the frequency of the A above middle C, in Hz.
+	// The zero value stands for 440 Hz.
+	ConcertA float64
+}
+
+var (
+	// PythagoreanTuning has pure fifths (3/2).
+	PythagoreanTuning = RegularTemperament{Fifth: 1200 * math.Log2(3.0/2)}
+	// QuarterCommaMeantone has pure major thirds (5/4), and fifths narrowed by
+	// a quarter of a syntonic comma.
+	QuarterCommaMeantone = RegularTemperament{Fifth: 1200 * math.Log2(5) / 4}
+)
+
+// NewEDO returns the equal division of the octave in given number of steps
+// (N-EDO), with A at given frequency. Notes are mapped to the steps of the
+// division through the line of fifths, using the division's closest step to a
+// pure fifth: in 19-EDO, C♯ and D♭ are different steps, while in 12-EDO, NewEDO
+// is equivalent to [EqualTemperament].
+//
+// This function panics if divisions isn't positive.
+func NewEDO(divisions int, concertA float64) RegularTemperament {
+	if divisions <= 0 {
+		panic("gohar: equal division of the octave in no steps")
+	}
+	step := 1200 / float64(divisions)
+	return RegularTemperament{
+		Fifth:    step * math.Round(math.Log2(3.0/2)*float64(divisions)),
+		ConcertA: concertA,
+	}
+}
+
+// Frequency returns the frequency of the note, in Hz.
+func (t RegularTemperament) Frequency(n Note) float64 {
+	cents := t.cents(n) - t.cents(NoteA)
+	return concertA(t.ConcertA) * math.Exp2(cents/1200)
+}
+
+// cents returns the position of the note in cents, relative to middle C.
+func (t RegularTemperament) cents(n Note) float64 {
+	fifths := fifthsOf(n.PitchClass)
+	// octaves to go down after stacking the fifths
+	octaves := (7*fifths - int(asPitch[n.Base()]+n.Alt())) / 12
+	return float64(fifths)*t.Fifth + 1200*float64(int(n.BaseOctave())-octaves)
+}
+
+// A JustIntonation tunes notes with whole-number frequency ratios to a tonic.
+type JustIntonation struct {
+	// Tonic is the pitch class that the ratios are relative to.
+	Tonic PitchClass
+	// Limit is the highest prime number in the ratios: 5 or 7.
+	// 7-limit tuning has a harmonic seventh (7/4) and septimal tritones (7/5 and
+	// 10/7). Any other value stands for 5-limit.
+	Limit int
+	// Reference is the tuning of the tonic in the middle octave.
+	// If nil, the tonic is tuned after the standard tuning.
+	Reference Tuning
+}
+
+// 5-limit ratios of the intervals within an octave.
+var justRatios = map[Interval]float64{
+	IntUnisson:           1,
+	IntMinorSecond:       16.0 / 15,
+	IntMajorSecond:       9.0 / 8,
+	IntAugmentedSecond:   75.0 / 64,
+	IntMinorThird:        6.0 / 5,
+	IntMajorThird:        5.0 / 4,
+	IntPerfectFourth:     4.0 / 3,
+	IntAugmentedFourth:   45.0 / 32,
+	IntDiminishedFifth:   64.0 / 45,
+	IntPerfectFifth:      3.0 / 2,
+	IntAugmentedFifth:    25.0 / 16,
+	IntMinorSixth:        8.0 / 5,
+	IntMajorSixth:        5.0 / 3,
+	IntAugmentedSixth:    225.0 / 128,
+	IntMinorSeventh:      9.0 / 5,
+	IntMajorSeventh:      15.0 / 8,
+	IntDiminishedSeventh: 128.0 / 75,
+}
+
+var septimalRatios = map[Interval]float64{
+	IntMinorSeventh:    7.0 / 4,
+	IntAugmentedFourth: 7.0 / 5,
+	IntDiminishedFifth: 10.0 / 7,
+}
+
+// Frequency returns the frequency of the note, in Hz.
+func (t JustIntonation) Frequency(n Note) float64 {
+	ref := t.Reference
+	if ref == nil {
+		ref = StandardTuning
+	}
+	tonic := Note{t.Tonic, 0}
+	i := IntervalBetween(tonic, n)
+	octaves := 0
+	for i.ScaleDiff < 0 {
+		i = i.Add(IntOctave)
+		octaves--
+	}
+	for i.ScaleDiff >= 7 {
+		i = i.Sub(IntOctave)
+		octaves++
+	}
+	return ref.Frequency(tonic) * t.ratio(i) * math.Exp2(float64(octaves))
+}
+
+// ratio returns the frequency ratio of an interval within an octave.
+func (t JustIntonation) ratio(i Interval) float64 {
+	if t.Limit == 7 {
+		if r, ok := septimalRatios[i]; ok {
+			return r
+		}
+	}
+	if r, ok := justRatios[i]; ok {
+		return r
+	}
+	// other intervals are altered by chromatic semitones (25/24) from the
+	// major or perfect interval of the same number.
+	natural := Interval{i.ScaleDiff, asPitch[i.ScaleDiff]}
+	return justRatios[natural] * math.Pow(25.0/24, float64(i.PitchDiff-natural.PitchDiff))
+}
+
+// A WellTemperament tunes the twelve pitch classes after fixed deviations from
+// equal temperament. Enharmonic notes have the same frequency.
+type WellTemperament struct {
+	// Offsets are the deviations from equal temperament of each pitch class,
+	// from C to B, in cents.
+	Offsets [12]float64
+	// ConcertA is the frequency of the A above middle C, in Hz.
+	// The zero value stands for 440 Hz.
+	ConcertA float64
+}
+
+var (
+	// WerckmeisterIII is Andreas Werckmeister's "correct temperament" No. 1
+	// (1691), with the fifths C-G, G-D, D-A and B-F♯ narrowed by a quarter of a
+	// pythagorean comma.
+	WerckmeisterIII = WellTemperament{Offsets: [12]float64{
+		0, -9.775, -7.820, -5.865, -9.775, -1.955,
+		-11.730, -3.910, -7.820, -11.730, -3.910, -7.820,
+	}}
+	// Vallotti is Francesco Vallotti's temperament, with the six fifths from F
+	// to B narrowed by a sixth of a pythagorean comma, and the others pure.
+	Vallotti = WellTemperament{Offsets: [12]float64{
+		0, -5.865, -3.910, -1.955, -7.820, 1.955,
+		-7.820, -1.955, -3.910, -5.865, 0, -9.775,
+	}}
+)
+
+// Frequency returns the frequency of the note, in Hz.
+func (t WellTemperament) Frequency(n Note) float64 {
+	p := n.Pitch()
+	cents := 100*float64(p-PitchA) + t.Offsets[p.Normalize()] - t.Offsets[PitchA]
+	return concertA(t.ConcertA) * math.Exp2(cents/1200)
+}
@@ -0,0 +1,139 @@
+package gohar
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func floatNear(want, got float64) error {
+	if math.Abs(want-got) > 1e-3 {
+		return fmt.Errorf("expected %f, got %f", want, got)
+	}
+	return nil
+}
+
+func TestPitchFrequency(t *testing.T) {
+	testCases := []struct {
+		Pitch  Pitch
+		Tuning EqualTemperament
+		Want   float64
+	}{
+		{PitchA, StandardTuning, 440},
+		{PitchA, EqualTemperament{}, 440},
+		{PitchA, OrchestraTuning, 442},
+		{PitchA, BaroqueTuning, 415},
+		{PitchA + 12, StandardTuning, 880},
+		{PitchA - 24, StandardTuning, 110},
+		{PitchC, StandardTuning, 261.625565},
+		{PitchC, OrchestraTuning, 262.814812},
+		{-39, StandardTuning, 27.5},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d@%g", tc.Pitch, tc.Tuning.ConcertA), func(t *testing.T) {
+			Expect(t, floatNear(tc.Want, tc.Pitch.Frequency(tc.Tuning)))
+		})
+	}
+}
+
+func TestPitchFromFrequency(t *testing.T) {
+	testCases := []struct {
+		Hz     float64
+		Tuning EqualTemperament
+		Pitch  Pitch
+		Cents  float64
+	}{
+		{440, StandardTuning, PitchA, 0},
+		{442, OrchestraTuning, PitchA, 0},
+		{442, StandardTuning, PitchA, 7.851415},
+		{261.625565, StandardTuning, PitchC, 0},
+		{415, StandardTuning, PitchAFlat, -1.270625},
+		{450, StandardTuning, PitchA, 38.905773},
+		{460, StandardTuning, PitchBFlat, -23.043595},
+		{1e9, StandardTuning, 127, 13539.191769},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%gHz@%g", tc.Hz, tc.Tuning.ConcertA), func(t *testing.T) {
+			p, cents := tc.Tuning.PitchFromFrequency(tc.Hz)
+			Expect(t,
+				pitchEqual(tc.Pitch, p),
+				floatNear(tc.Cents, cents),
+			)
+		})
+	}
+	p, cents := PitchFromFrequency(440)
+	Expect(t,
+		pitchEqual(PitchA, p),
+		floatNear(0, cents),
+		ShouldPanic(func() { PitchFromFrequency(0) }),
+		ShouldPanic(func() { PitchFromFrequency(-440) }),
+		ShouldPanic(func() { PitchFromFrequency(math.NaN()) }),
+	)
+}
+
+func TestCents(t *testing.T) {
+	Expect(t,
+		floatNear(1200, Cents(440, 880)),
+		floatNear(-1200, Cents(880, 440)),
+		floatNear(100, Cents(PitchA.Frequency(StandardTuning), PitchBFlat.Frequency(StandardTuning))),
+		floatNear(880, AddCents(440, 1200)),
+		floatNear(PitchAFlat.Frequency(StandardTuning), AddCents(440, -100)),
+	)
+}
+
+func TestTuningFrequency(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Tuning Tuning
+		Note   Note
+		Want   float64
+	}{
+		{"12-TET A", StandardTuning, NoteA, 440},
+		{"12-TET C#", StandardTuning, NoteC.Sharp(), 277.182631},
+		{"12-TET Db", StandardTuning, NoteD.Flat(), 277.182631},
+		{"12-EDO C#", NewEDO(12, 440), NoteC.Sharp(), 277.182631},
+		{"12-EDO B#", NewEDO(12, 440), NoteB.Sharp(), 523.251131},
+		{"12-EDO Cb", NewEDO(12, 440), NoteC.Octave(1).Flat(), 493.883301},
+		{"19-EDO C#", NewEDO(19, 440), NoteC.Sharp(), 273.832370},
+		{"19-EDO Db", NewEDO(19, 440), NoteD.Flat(), 284.006624},
+		{"meantone A", QuarterCommaMeantone, NoteA, 440},
+		{"meantone A at 415", RegularTemperament{QuarterCommaMeantone.Fifth, 415}, NoteA, 415},
+		{"meantone C#", QuarterCommaMeantone, NoteC.Sharp(), 275},
+		{"meantone Db", QuarterCommaMeantone, NoteD.Flat(), 281.6},
+		{"meantone E", QuarterCommaMeantone, NoteE, 328.976732},
+		{"pythagorean E", PythagoreanTuning, NoteE, 330},
+		{"pythagorean C", PythagoreanTuning, NoteC, 260.740741},
+		{"pythagorean C'", PythagoreanTuning, NoteC.Octave(1), 521.481481},
+		{"pythagorean F#", PythagoreanTuning, NoteF.Sharp(), 371.25},
+		{"pythagorean Gb", PythagoreanTuning, NoteG.Flat(), 366.253112},
+		{"just C", JustIntonation{Tonic: PitchClassC}, NoteC, 261.625565},
+		{"just E", JustIntonation{Tonic: PitchClassC}, NoteE, 327.031957},
+		{"just A", JustIntonation{Tonic: PitchClassC}, NoteA, 436.042609},
+		{"just E,", JustIntonation{Tonic: PitchClassC}, NoteE.Octave(-1), 163.515978},
+		{"just F#", JustIntonation{Tonic: PitchClassC}, NoteF.Sharp(), 367.910951},
+		{"just Bb 5-limit", JustIntonation{Tonic: PitchClassC}, NoteB.Flat(), 470.926017},
+		{"just Bb 7-limit", JustIntonation{Tonic: PitchClassC, Limit: 7}, NoteB.Flat(), 457.844739},
+		{"just F# 7-limit", JustIntonation{Tonic: PitchClassC, Limit: 7}, NoteF.Sharp(), 366.275791},
+		{"just A in A", JustIntonation{Tonic: PitchClassA}, NoteA, 440},
+		{"just C# in A", JustIntonation{Tonic: PitchClassA}, NoteC.Octave(1).Sharp(), 550},
+		{"just E in A", JustIntonation{Tonic: PitchClassA}, NoteE, 330},
+		{"just E in A at 415", JustIntonation{Tonic: PitchClassA, Reference: BaroqueTuning}, NoteE, 311.25},
+		{"werckmeister A", WerckmeisterIII, NoteA, 440},
+		{"werckmeister C", WerckmeisterIII, NoteC, 263.404232},
+		{"werckmeister E", WerckmeisterIII, NoteE, 330},
+		{"vallotti C", Vallotti, NoteC, 262.513392},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			Expect(t, floatNear(tc.Want, tc.Tuning.Frequency(tc.Note)))
+		})
+	}
+	Expect(t,
+		floatNear(440, PitchA.Frequency(nil)),
+		floatNear(281.6, PitchCSharp.Frequency(QuarterCommaMeantone)),
+		ShouldPanic(func() { NewEDO(0, 440) }),
+		ShouldPanic(func() { NewEDO(-12, 440) }),
+	)
+}
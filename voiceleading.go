@@ -0,0 +1,205 @@
+package gohar
+
+import (
+	"slices"
+)
+
+// VoiceLeadingOptions constrain the voicings chosen by [VoiceLead].
+type VoiceLeadingOptions struct {
+	// Low and High are the range of the voices. If both are zero, the range
+	// of the starting voicing, widened by a fifth on each side, is used.
+	Low, High Pitch
+	// Melody, if set, is the pitch of the top voice for each chord.
+	// Melody notes don't have to be chord tones.
+	Melody []Pitch
+}
+
+// VoiceLead chooses a voicing for each of the chords that follow the start
+// voicing, so as to minimize the total movement of the voices (the sum of the
+// distances in semitones between the successive pitches of each voice).
+// It returns the voicings along with the movement from the previous voicing to
+// each of them.
+//
+// Voicings have as many voices as the start voicing, without crossings or
+// unisons. They contain every chord tone if there are enough voices, or at
+// least the third and the seventh of the chord otherwise. The bass of slash
+// chords is always the lowest voice. Apart from the bass, adjacent voices are
+// never more than an octave apart.
+//
+// ErrInvalidVoicing is returned if the start voicing is empty or has crossing
+// voices, or if the melody doesn't have one pitch per chord. ErrNoVoicing is
+// returned if a chord cannot be voiced within the constraints.
+func VoiceLead(start []Note, chords []Chord, opts VoiceLeadingOptions) ([][]Note, []int, error) {
+	if len(start) == 0 {
+		return nil, nil, wrapErrorf(ErrInvalidVoicing, "empty start voicing")
+	}
+	for i := 1; i < len(start); i++ {
+		if start[i].Pitch() <= start[i-1].Pitch() {
+			return nil, nil, wrapErrorf(ErrInvalidVoicing, "crossing voices in %v", start)
+		}
+	}
+	if opts.Melody != nil && len(opts.Melody) != len(chords) {
+		return nil, nil, wrapErrorf(ErrInvalidVoicing,
+			"melody has %d notes for %d chords", len(opts.Melody), len(chords),
+		)
+	}
+	if opts.Low == 0 && opts.High == 0 {
+		opts.Low = start[0].Pitch() - PitchDiffPerfectFifth
+		opts.High = start[len(start)-1].Pitch() + PitchDiffPerfectFifth
+	}
+	if len(chords) == 0 {
+		return nil, nil, nil
+	}
+
+	// Viterbi: cost[j] is the lowest total movement that leads to the j-th
+	// candidate voicing of the current chord, and from[i][j] the candidate of
+	// the previous chord it comes from.
+	prev := [][]Note{start}
+	cost := []int{0}
+	candidates := make([][][]Note, len(chords))
+	from := make([][]int, len(chords))
+	for i, chord := range chords {
+		melody := Pitch(0)
+		if opts.Melody != nil {
+			melody = opts.Melody[i]
+		}
+		candidates[i] = candidateVoicings(chord, len(start), opts.Low, opts.High, opts.Melody != nil, melody)
+		if len(candidates[i]) == 0 {
+			return nil, nil, wrapErrorf(ErrNoVoicing, "%s (chord %d)", chord, i+1)
+		}
+		next := make([]int, len(candidates[i]))
+		from[i] = make([]int, len(candidates[i]))
+		for j, v := range candidates[i] {
+			next[j] = -1
+			for k, p := range prev {
+				if c := cost[k] + voiceMovement(p, v); next[j] < 0 || c < next[j] {
+					next[j], from[i][j] = c, k
+				}
+			}
+		}
+		prev, cost = candidates[i], next
+	}
+
+	voicings := make([][]Note, len(chords))
+	best := 0
+	for j := range cost {
+		if cost[j] < cost[best] {
+			best = j
+		}
+	}
+	for i := len(chords) - 1; i >= 0; i-- {
+		voicings[i] = candidates[i][best]
+		best = from[i][best]
+	}
+	movements := make([]int, len(chords))
+	for i := range voicings {
+		previous := start
+		if i > 0 {
+			previous = voicings[i-1]
+		}
+		movements[i] = voiceMovement(previous, voicings[i])
+	}
+	return voicings, movements, nil
+}
+
+// voiceMovement returns the sum of the distances between the pitches of each voice.
+func voiceMovement(a, b []Note) int {
+	var total int
+	for i := range a {
+		d := int(b[i].Pitch() - a[i].Pitch())
+		total += max(d, -d)
+	}
+	return total
+}
+
+// candidateVoicings returns the voicings of the chord with given number of
+// voices that satisfy the constraints of [VoiceLead], in ascending order.
+func candidateVoicings(chord Chord, voices int, low, high Pitch, hasMelody bool, melody Pitch) [][]Note {
+	tones := make(map[Pitch]PitchClass)
+	for pc := range chord.PitchClasses() {
+		tones[pc.Pitch(0).Normalize()] = pc
+	}
+	var required []Pitch
+	if len(tones) > voices {
+		t := newChordTones(chord.Pattern)
+		for _, i := range []*Interval{t.third, t.seventh} {
+			if i != nil {
+				required = append(required, chord.Root.Transpose(*i).Pitch(0).Normalize())
+			}
+		}
+	} else {
+		for p := range tones {
+			required = append(required, p)
+		}
+	}
+
+	var top Note
+	if hasMelody {
+		pc, ok := tones[melody.Normalize()]
+		if !ok {
+			pc = SpellPitchClasses([]Pitch{melody}, SpellingContext{Chord: chord})[0]
+		}
+		top = NoteWithPitch(pc, melody)
+		if voices == 1 {
+			return [][]Note{{top}}
+		}
+		high = min(high, melody-1)
+	}
+
+	var (
+		voicings [][]Note
+		current  = make([]Pitch, 0, voices)
+	)
+	var build func(from Pitch)
+	build = func(from Pitch) {
+		n := len(current)
+		if n == voices || (hasMelody && n == voices-1) {
+			pitches := slices.Clone(current)
+			if hasMelody {
+				if melody-pitches[n-1] > 12 && n > 1 {
+					return
+				}
+				pitches = append(pitches, melody)
+			}
+			if !coversTones(pitches, required) {
+				return
+			}
+			voicing := make([]Note, len(pitches))
+			for i, p := range pitches {
+				if pc, ok := tones[p.Normalize()]; ok {
+					voicing[i] = NoteWithPitch(pc, p)
+				}
+			}
+			if hasMelody {
+				voicing[len(voicing)-1] = top
+			}
+			voicings = append(voicings, voicing)
+			return
+		}
+		to := high
+		if n > 1 {
+			to = min(to, current[n-1]+12)
+		}
+		for p := from; p <= to; p++ {
+			pc, ok := tones[p.Normalize()]
+			if !ok || (n == 0 && chord.HasBass() && pc != chord.Bass) {
+				continue
+			}
+			current = append(current, p)
+			build(p + 1)
+			current = current[:n]
+		}
+	}
+	build(low)
+	return voicings
+}
+
+// coversTones returns true if every required pitch class is in the pitches.
+func coversTones(pitches []Pitch, required []Pitch) bool {
+	for _, r := range required {
+		if !slices.ContainsFunc(pitches, func(p Pitch) bool { return p.Normalize() == r }) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestVoiceLead(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Start     []Note
+		Chords    []string
+		Opts      VoiceLeadingOptions
+		Want      [][]Note
+		Movements []int
+	}{
+		{
+			Name:   "ii-V-I",
+			Start:  []Note{NoteF, NoteA, NoteC.Octave(1), NoteE.Octave(1)},
+			Chords: []string{"G7", "Cmaj7"},
+			Want: [][]Note{
+				{NoteF, NoteG, NoteB, NoteD.Octave(1)},
+				{NoteE, NoteG, NoteB, NoteC.Octave(1)},
+			},
+			Movements: []int{5, 3},
+		},
+		{
+			Name:   "I-IV-V-I",
+			Start:  []Note{NoteC, NoteE, NoteG},
+			Chords: []string{"F", "G", "C"},
+			Want: [][]Note{
+				{NoteC, NoteF, NoteA},
+				{NoteB.Octave(-1), NoteD, NoteG},
+				{NoteC, NoteE, NoteG},
+			},
+			Movements: []int{3, 6, 3},
+		},
+		{
+			Name:   "melody",
+			Start:  []Note{NoteC, NoteE, NoteG},
+			Chords: []string{"F", "G", "C"},
+			Opts:   VoiceLeadingOptions{Melody: []Pitch{9, 11, 12}},
+			Want: [][]Note{
+				{NoteC, NoteF, NoteA},
+				{NoteD, NoteG, NoteB},
+				{NoteE, NoteG, NoteC.Octave(1)},
+			},
+			Movements: []int{3, 6, 3},
+		},
+		{
+			Name:   "slash chords",
+			Start:  []Note{NoteC, NoteE, NoteG},
+			Chords: []string{"C/E", "F/A"},
+			Opts:   VoiceLeadingOptions{Low: -12, High: 12},
+			Want: [][]Note{
+				{NoteE.Octave(-1), NoteC, NoteG},
+				{NoteA.Octave(-1), NoteC, NoteF},
+			},
+			Movements: []int{12, 7},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			chords := make([]Chord, len(tc.Chords))
+			for i, symbol := range tc.Chords {
+				var err error
+				chords[i], err = ParseChord(symbol)
+				Require(t, NoError(err))
+			}
+			voicings, movements, err := VoiceLead(tc.Start, chords, tc.Opts)
+			Expect(t,
+				NoError(err),
+				Equal(tc.Want, voicings),
+				Equal(tc.Movements, movements),
+			)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		c := []Chord{{Root: PitchClassC, Pattern: ChordPatternMajor}}
+		triad := []Note{NoteC, NoteE, NoteG}
+		_, _, err := VoiceLead(nil, c, VoiceLeadingOptions{})
+		Expect(t, IsError(ErrInvalidVoicing, err))
+		_, _, err = VoiceLead([]Note{NoteE, NoteC}, c, VoiceLeadingOptions{})
+		Expect(t, IsError(ErrInvalidVoicing, err))
+		_, _, err = VoiceLead(triad, c, VoiceLeadingOptions{Melody: []Pitch{7, 7}})
+		Expect(t, IsError(ErrInvalidVoicing, err))
+		_, _, err = VoiceLead(triad, c, VoiceLeadingOptions{Low: 0, High: 3})
+		Expect(t, IsError(ErrNoVoicing, err))
+	})
+}
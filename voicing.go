@@ -0,0 +1,302 @@
+package gohar
+
+import (
+	"cmp"
+	"slices"
+)
+
+// A VoicingKind is a way of laying out the notes of a chord.
+type VoicingKind int
+
+const (
+	// VoicingClose stacks the chord tones as closely as possible, in every inversion.
+	VoicingClose VoicingKind = iota
+	// VoicingDrop2 drops the second highest note of close voicings by an octave.
+	VoicingDrop2
+	// VoicingDrop3 drops the third highest note of close voicings by an octave.
+	VoicingDrop3
+	// VoicingDrop24 drops the second and fourth highest notes of close voicings by an octave.
+	VoicingDrop24
+	// VoicingShell only keeps the root, the third and the seventh (1-3-7 or 1-7-3).
+	VoicingShell
+	// VoicingRootlessA stacks the 3rd, 5th (or 13th), 7th and 9th, without the root.
+	VoicingRootlessA
+	// VoicingRootlessB stacks the 7th, 9th, 3rd and 5th (or 13th), without the root.
+	VoicingRootlessB
+	// VoicingQuartal stacks chord tones (including extensions) a fourth apart.
+	VoicingQuartal
+	// VoicingSpread puts the root in the bass, then the 7th (or 5th), then the
+	// other tones above the 10th.
+	VoicingSpread
+)
+
+var voicingKindNames = [...]string{
+	VoicingClose:     "close",
+	VoicingDrop2:     "drop 2",
+	VoicingDrop3:     "drop 3",
+	VoicingDrop24:    "drop 2&4",
+	VoicingShell:     "shell",
+	VoicingRootlessA: "rootless A",
+	VoicingRootlessB: "rootless B",
+	VoicingQuartal:   "quartal",
+	VoicingSpread:    "spread",
+}
+
+// String returns the name of the voicing kind.
+// "<invalid>" is returned if the kind is invalid.
+func (k VoicingKind) String() string {
+	if k < 0 || int(k) >= len(voicingKindNames) {
+		return "<invalid>"
+	}
+	return voicingKindNames[k]
+}
+
+// Voicings returns the voicings of given kind whose notes all lie within the
+// [low, high] pitch range, sorted from the lowest to the highest.
+//
+// Chord tones are laid out as with [ChordPattern.Unpack]: extensions (9ths,
+// 11ths and 13ths) are placed on top of close and drop voicings. The bass of
+// slash chords is ignored.
+//
+// ErrInvalidVoicing is returned if the kind is invalid, or if the chord lacks
+// the tones needed for that kind of voicing (e.g. a shell voicing of a triad).
+func (c Chord) Voicings(kind VoicingKind, low, high Pitch) ([][]Note, error) {
+	templates, err := voicingTemplates(c.Pattern, kind)
+	if err != nil {
+		return nil, wrapErrorf(err, "%s voicing of %s", kind, c)
+	}
+	var voicings [][]Note
+	for _, template := range templates {
+		for oct := low.GetOctave() - 3; oct <= high.GetOctave()+1; oct++ {
+			root := Note{c.Root, oct}
+			voicing := make([]Note, 0, len(template))
+			for _, i := range template {
+				n := root.Transpose(i)
+				if p := n.Pitch(); p < low || p > high {
+					break
+				}
+				voicing = append(voicing, n)
+			}
+			if len(voicing) == len(template) {
+				voicings = append(voicings, voicing)
+			}
+		}
+	}
+	slices.SortStableFunc(voicings, func(a, b []Note) int {
+		return cmp.Compare(a[0].Pitch(), b[0].Pitch())
+	})
+	return voicings, nil
+}
+
+// chordTones sorts the unpacked intervals of a chord by function.
+type chordTones struct {
+	all        []Interval
+	core       []Interval // tones within the first octave
+	extensions []Interval // tones above the octave
+	third      *Interval  // third, or suspended fourth or second
+	fifth      *Interval
+	seventh    *Interval // seventh, or sixth
+	ninth      *Interval
+	thirteenth *Interval
+}
+
+func newChordTones(c ChordPattern) chordTones {
+	t := chordTones{all: c.Unpack().AsIntervals()}
+	for n := range t.all {
+		i := &t.all[n]
+		if i.ScaleDiff < 7 {
+			t.core = append(t.core, *i)
+		} else {
+			t.extensions = append(t.extensions, *i)
+		}
+		switch i.ScaleDiff {
+		case 2:
+			t.third = i
+		case 3, 1:
+			if t.third == nil || t.third.ScaleDiff != 2 {
+				t.third = i
+			}
+		case 4:
+			t.fifth = i
+		case 5:
+			if t.seventh == nil {
+				t.seventh = i
+			}
+		case 6:
+			t.seventh = i
+		case 8:
+			t.ninth = i
+		case 9:
+			// the ♯9 of altered chords is spelled as a ♭10
+			if i.PitchDiff == PitchDiffMinorTenth && t.ninth == nil {
+				t.ninth = i
+			}
+		case 12:
+			t.thirteenth = i
+		}
+	}
+	return t
+}
+
+// voicingTemplates returns the ascending intervals of the voicings of a chord,
+// relative to its root.
+func voicingTemplates(c ChordPattern, kind VoicingKind) ([][]Interval, error) {
+	t := newChordTones(c)
+	switch kind {
+	case VoicingClose, VoicingDrop2, VoicingDrop3, VoicingDrop24:
+		var dropped []int
+		minNotes := 1
+		switch kind {
+		case VoicingDrop2:
+			dropped, minNotes = []int{2}, 3
+		case VoicingDrop3:
+			dropped, minNotes = []int{3}, 4
+		case VoicingDrop24:
+			dropped, minNotes = []int{2, 4}, 4
+		}
+		if len(t.core) < minNotes {
+			return nil, ErrInvalidVoicing
+		}
+		var templates [][]Interval
+		for r := range t.core {
+			template := stackIntervals(append(slices.Clone(t.core[r:]), t.core[:r]...))
+			for _, d := range dropped {
+				i := len(template) - d
+				template[i] = template[i].Sub(IntOctave)
+			}
+			slices.SortFunc(template, compareIntervals)
+			templates = append(templates, stackOnTop(template, t.extensions...))
+		}
+		return templates, nil
+
+	case VoicingShell:
+		if t.third == nil || t.seventh == nil {
+			return nil, ErrInvalidVoicing
+		}
+		return [][]Interval{
+			stackIntervals([]Interval{IntUnisson, *t.third, *t.seventh}),
+			stackIntervals([]Interval{IntUnisson, *t.seventh, *t.third}),
+		}, nil
+
+	case VoicingRootlessA, VoicingRootlessB:
+		if t.third == nil || t.seventh == nil {
+			return nil, ErrInvalidVoicing
+		}
+		ninth := IntMajorNinth
+		if t.ninth != nil {
+			ninth = *t.ninth
+		}
+		fifth := t.fifth
+		if t.thirteenth != nil {
+			fifth = t.thirteenth
+		}
+		var tones []Interval
+		if kind == VoicingRootlessA {
+			tones = []Interval{*t.third, *t.seventh, ninth}
+			if fifth != nil {
+				tones = slices.Insert(tones, 1, *fifth)
+			}
+		} else {
+			tones = []Interval{*t.seventh, ninth, *t.third}
+			if fifth != nil {
+				tones = append(tones, *fifth)
+			}
+		}
+		return [][]Interval{stackIntervals(tones)}, nil
+
+	case VoicingQuartal:
+		var templates [][]Interval
+		for _, start := range t.all {
+			template := []Interval{start}
+			for {
+				next, ok := t.fourthAbove(template[len(template)-1])
+				if !ok || slices.ContainsFunc(template, func(i Interval) bool {
+					return simpleInterval(i) == simpleInterval(next)
+				}) {
+					break
+				}
+				template = append(template, next)
+			}
+			if len(template) >= 3 {
+				templates = append(templates, template)
+			}
+		}
+		if len(templates) == 0 {
+			return nil, ErrInvalidVoicing
+		}
+		return templates, nil
+
+	case VoicingSpread:
+		if t.third == nil {
+			return nil, ErrInvalidVoicing
+		}
+		guide := t.seventh
+		if guide == nil {
+			guide = t.fifth
+		}
+		if guide == nil {
+			return nil, ErrInvalidVoicing
+		}
+		tones := []Interval{IntUnisson, *guide, t.third.Add(IntOctave)}
+		for _, i := range t.all[1:] {
+			if i != *guide && i != *t.third {
+				tones = append(tones, i)
+			}
+		}
+		return [][]Interval{stackIntervals(tones)}, nil
+	}
+	return nil, ErrInvalidVoicing
+}
+
+// fourthAbove returns the chord tone a perfect or augmented fourth above given
+// interval, if any.
+func (t chordTones) fourthAbove(i Interval) (Interval, bool) {
+	for _, fourth := range []Interval{IntPerfectFourth, IntAugmentedFourth} {
+		target := i.Add(fourth)
+		for _, tone := range t.all {
+			if simpleInterval(tone) == simpleInterval(target) {
+				return target, true
+			}
+		}
+	}
+	return Interval{}, false
+}
+
+// stackIntervals places each interval in the first octave above the previous
+// one. The first interval is left untouched.
+func stackIntervals(intervals []Interval) []Interval {
+	stacked := make([]Interval, 0, len(intervals))
+	for _, i := range intervals {
+		if len(stacked) > 0 {
+			prev := stacked[len(stacked)-1]
+			i = simpleInterval(i)
+			for i.PitchDiff <= prev.PitchDiff {
+				i = i.Add(IntOctave)
+			}
+		}
+		stacked = append(stacked, i)
+	}
+	return stacked
+}
+
+// stackOnTop stacks the extra intervals above the highest interval of the voicing.
+func stackOnTop(voicing []Interval, extra ...Interval) []Interval {
+	top := len(voicing) - 1
+	return append(voicing[:top], stackIntervals(append([]Interval{voicing[top]}, extra...))...)
+}
+
+// simpleInterval reduces an ascending interval within the first octave
+// (unlike [Interval.Simple], octaves are reduced to unisons).
+func simpleInterval(i Interval) Interval {
+	for i.ScaleDiff >= 7 {
+		i = i.Sub(IntOctave)
+	}
+	for i.ScaleDiff < 0 {
+		i = i.Add(IntOctave)
+	}
+	return i
+}
+
+func compareIntervals(a, b Interval) int {
+	return cmp.Or(cmp.Compare(a.PitchDiff, b.PitchDiff), cmp.Compare(a.ScaleDiff, b.ScaleDiff))
+}
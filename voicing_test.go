@@ -0,0 +1,112 @@
+package gohar
+
+import (
+	"testing"
+
+	. "github.com/ArnaudCalmettes/gohar/test/helpers"
+)
+
+func TestVoicingKindString(t *testing.T) {
+	Expect(t,
+		Equal("drop 2", VoicingDrop2.String()),
+		Equal("rootless B", VoicingRootlessB.String()),
+		Equal("<invalid>", VoicingKind(-1).String()),
+		Equal("<invalid>", VoicingKind(42).String()),
+	)
+}
+
+func TestChordVoicings(t *testing.T) {
+	type voicings = [][]Note
+	testCases := []struct {
+		Symbol    string
+		Kind      VoicingKind
+		Low, High Pitch
+		Want      voicings
+	}{
+		{
+			"C", VoicingClose, 0, 16,
+			voicings{
+				{NoteC, NoteE, NoteG},
+				{NoteE, NoteG, NoteC.Octave(1)},
+				{NoteG, NoteC.Octave(1), NoteE.Octave(1)},
+			},
+		},
+		{
+			"C9", VoicingClose, 0, 14,
+			voicings{
+				{NoteC, NoteE, NoteG, NoteB.Flat(), NoteD.Octave(1)},
+				{NoteE, NoteG, NoteB.Flat(), NoteC.Octave(1), NoteD.Octave(1)},
+			},
+		},
+		{
+			"Cmaj7", VoicingDrop2, -12, 7,
+			voicings{
+				{NoteC.Octave(-1), NoteG.Octave(-1), NoteB.Octave(-1), NoteE},
+				{NoteE.Octave(-1), NoteB.Octave(-1), NoteC, NoteG},
+			},
+		},
+		{
+			"Cmaj7", VoicingDrop3, -12, 7,
+			voicings{{NoteC.Octave(-1), NoteB.Octave(-1), NoteE, NoteG}},
+		},
+		{
+			"Cmaj7", VoicingDrop24, -24, 0,
+			voicings{
+				{NoteC.Octave(-2), NoteG.Octave(-2), NoteE.Octave(-1), NoteB.Octave(-1)},
+				{NoteE.Octave(-2), NoteB.Octave(-2), NoteG.Octave(-1), NoteC},
+			},
+		},
+		{
+			"Dm7", VoicingShell, 0, 14,
+			voicings{{NoteD, NoteF, NoteC.Octave(1)}},
+		},
+		{
+			"G13", VoicingRootlessA, -2, 10,
+			voicings{{NoteB.Octave(-1), NoteE, NoteF, NoteA}},
+		},
+		{
+			"C7alt", VoicingRootlessA, 0, 16,
+			voicings{{NoteE, NoteA.Flat(), NoteB.Flat(), NoteE.Flat().Octave(1)}},
+		},
+		{
+			"C7#9", VoicingRootlessB, 0, 20,
+			voicings{{NoteB.Flat(), NoteD.Sharp().Octave(1), NoteE.Octave(1), NoteG.Octave(1)}},
+		},
+		{
+			"Dm9", VoicingRootlessB, 0, 12,
+			voicings{{NoteC, NoteE, NoteF, NoteA}},
+		},
+		{
+			"Dm11", VoicingQuartal, 2, 17,
+			voicings{
+				{NoteD, NoteG, NoteC.Octave(1), NoteF.Octave(1)},
+				{NoteG, NoteC.Octave(1), NoteF.Octave(1)},
+			},
+		},
+		{
+			"Cmaj7", VoicingSpread, -12, 7,
+			voicings{{NoteC.Octave(-1), NoteB.Octave(-1), NoteE, NoteG}},
+		},
+		{"Cmaj7", VoicingClose, 0, 6, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Symbol+" "+tc.Kind.String(), func(t *testing.T) {
+			chord, err := ParseChord(tc.Symbol)
+			Require(t, NoError(err))
+			got, err := chord.Voicings(tc.Kind, tc.Low, tc.High)
+			Expect(t, NoError(err), Equal(tc.Want, got))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		triad := Chord{Root: PitchClassC, Pattern: ChordPatternMajor}
+		for _, kind := range []VoicingKind{
+			VoicingDrop3, VoicingDrop24, VoicingShell, VoicingRootlessA,
+			VoicingRootlessB, VoicingQuartal, VoicingKind(42),
+		} {
+			_, err := triad.Voicings(kind, -24, 24)
+			Expect(t, IsErrorf(ErrInvalidVoicing, err, "%s", kind))
+		}
+	})
+}